@@ -3,6 +3,7 @@ package generator
 import (
 	"crypto/ecdsa"
 	"encoding/json"
+	"fmt"
 	"github.com/umbracle/ethgo"
 	"io/ioutil"
 	"math/big"
@@ -72,16 +73,17 @@ type GeneratorParams struct {
 	ContractAddress  ethgo.Address
 }
 
-// ReadContractArtifact reads the contract bytecode from the specified path
+// ReadContractArtifact reads the contract bytecode from the specified path.
+// The returned errors are wrapped with the path of the artifact
 func ReadContractArtifact(configPath string) (*ContractArtifact, error) {
 	rawData, readErr := ioutil.ReadFile(configPath)
 	if readErr != nil {
-		return nil, readErr
+		return nil, fmt.Errorf("loading artifact %q: %w", configPath, readErr)
 	}
 
 	var artifact ContractArtifact
 	if jsonErr := json.Unmarshal(rawData, &artifact); jsonErr != nil {
-		return nil, jsonErr
+		return nil, fmt.Errorf("loading artifact %q: %w", configPath, jsonErr)
 	}
 
 	return &artifact, nil
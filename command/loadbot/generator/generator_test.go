@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadContractArtifact(t *testing.T) {
+	dir := t.TempDir()
+
+	writeArtifact := func(t *testing.T, name, content string) string {
+		t.Helper()
+
+		path := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+		return path
+	}
+
+	t.Run("should read a valid artifact", func(t *testing.T) {
+		path := writeArtifact(t, "valid.json", `{"bytecode": "0x6080"}`)
+
+		artifact, err := ReadContractArtifact(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "0x6080", artifact.Bytecode)
+	})
+
+	t.Run("should wrap the error of a nonexistent file with its path", func(t *testing.T) {
+		path := filepath.Join(dir, "nonexistent.json")
+
+		_, err := ReadContractArtifact(path)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.Contains(t, err.Error(), path)
+	})
+
+	t.Run("should wrap the error of a malformed file with its path", func(t *testing.T) {
+		path := writeArtifact(t, "malformed.json", `{"bytecode": `)
+
+		_, err := ReadContractArtifact(path)
+
+		var syntaxErr *json.SyntaxError
+
+		assert.ErrorAs(t, err, &syntaxErr)
+		assert.Contains(t, err.Error(), path)
+	})
+}
@@ -19,6 +19,7 @@ var (
 	errInvalidMode   = errors.New("invalid loadbot mode")
 	errInvalidValues = errors.New("invalid values")
 	errContractPath  = errors.New("contract path not specified")
+	errNoConstructor = errors.New("contract has no constructor")
 )
 
 const (
@@ -256,8 +257,10 @@ func (p *loadbotParams) initContractArtifactAndArgs() error {
 			ABI:      abi.MustNewABI(ERC20ABI),
 		}
 
-		if ctrArgs, err = abi.Encode(
-			[]string{erc20TokenSupply, erc20TokenName, erc20TokenSymbol}, ctrArtifact.ABI.Constructor.Inputs); err != nil {
+		if ctrArgs, err = encodeConstructorArgs(
+			ctrArtifact.ABI,
+			[]string{erc20TokenSupply, erc20TokenName, erc20TokenSymbol},
+		); err != nil {
 			return fmt.Errorf("failed to encode erc20 constructor parameters: %w", err)
 		}
 
@@ -267,9 +270,10 @@ func (p *loadbotParams) initContractArtifactAndArgs() error {
 			ABI:      abi.MustNewABI(ERC721ABI),
 		}
 
-		if ctrArgs, err = abi.Encode(
+		if ctrArgs, err = encodeConstructorArgs(
+			ctrArtifact.ABI,
 			[]string{erc721TokenName, erc721TokenSymbol},
-			ctrArtifact.ABI.Constructor.Inputs); err != nil {
+		); err != nil {
 			return fmt.Errorf("failed to encode erc721 constructor parameters: %w", err)
 		}
 
@@ -285,3 +289,16 @@ func (p *loadbotParams) initContractArtifactAndArgs() error {
 
 	return nil
 }
+
+// encodeConstructorArgs encodes the constructor arguments using the constructor inputs of the contract ABI
+func encodeConstructorArgs(contractABI *abi.ABI, args []string) ([]byte, error) {
+	if contractABI.Constructor == nil {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("%w but %d params were supplied", errNoConstructor, len(args))
+		}
+
+		return nil, nil
+	}
+
+	return abi.Encode(args, contractABI.Constructor.Inputs)
+}
@@ -0,0 +1,82 @@
+package loadbot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo/abi"
+)
+
+func TestEncodeConstructorArgs(t *testing.T) {
+	var (
+		noConstructorABI = abi.MustNewABI(`[
+			{"type": "function", "name": "getCount", "inputs": [], "outputs": [{"type": "uint256"}]}
+		]`)
+		constructorABI = abi.MustNewABI(`[
+			{
+				"type": "constructor",
+				"inputs": [{"name": "name", "type": "string"}, {"name": "symbol", "type": "string"}]
+			}
+		]`)
+	)
+
+	testTable := []struct {
+		name          string
+		contractABI   *abi.ABI
+		args          []string
+		expectedEmpty bool
+		expectedErr   error
+		shouldFail    bool
+	}{
+		{
+			"no constructor with args",
+			noConstructorABI,
+			[]string{"ZexCoin"},
+			true,
+			errNoConstructor,
+			true,
+		},
+		{
+			"no constructor without args",
+			noConstructorABI,
+			nil,
+			true,
+			nil,
+			false,
+		},
+		{
+			"wrong number of args",
+			constructorABI,
+			[]string{"ZexCoin"},
+			true,
+			nil,
+			true,
+		},
+		{
+			"matching args",
+			constructorABI,
+			[]string{"ZexCoin", "ZEX"},
+			false,
+			nil,
+			false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			encodedArgs, err := encodeConstructorArgs(testCase.contractABI, testCase.args)
+
+			if testCase.shouldFail {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			}
+
+			assert.Equal(t, testCase.expectedEmpty, len(encodedArgs) == 0)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package staking
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// StakingABIFingerprint is the fingerprint of the ABI of the embedded staking SC
+	StakingABIFingerprint = types.StringToHash("0x1fd7fc578c7174a8d4390a4849ffc7aa19098b65e5ac8efbba3147a98340803a")
+
+	ErrABIFingerprintMismatch = errors.New("ABI does not match the embedded staking SC ABI")
+)
+
+// ABIFingerprint returns the keccak256 hash of the canonicalized ABI JSON.
+// The ABI entries are sorted, and the JSON is stripped of whitespace
+// and has its object keys sorted, so the fingerprint does not depend on formatting
+func ABIFingerprint(abiJSON []byte) (types.Hash, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(abiJSON, &entries); err != nil {
+		return types.ZeroHash, fmt.Errorf("unable to parse ABI, %w", err)
+	}
+
+	canonicalEntries := make([][]byte, len(entries))
+
+	for indx, entry := range entries {
+		var decoded interface{}
+		if err := json.Unmarshal(entry, &decoded); err != nil {
+			return types.ZeroHash, fmt.Errorf("unable to parse ABI entry, %w", err)
+		}
+
+		// encoding/json sorts the map keys and doesn't add any whitespace
+		canonicalEntry, err := json.Marshal(decoded)
+		if err != nil {
+			return types.ZeroHash, fmt.Errorf("unable to encode ABI entry, %w", err)
+		}
+
+		canonicalEntries[indx] = canonicalEntry
+	}
+
+	sort.Slice(canonicalEntries, func(i, j int) bool {
+		return bytes.Compare(canonicalEntries[i], canonicalEntries[j]) < 0
+	})
+
+	canonicalABI := append([]byte{'['}, bytes.Join(canonicalEntries, []byte{','})...)
+	canonicalABI = append(canonicalABI, ']')
+
+	return types.BytesToHash(keccak.Keccak256(nil, canonicalABI)), nil
+}
+
+// VerifyStakingABI checks that the passed in ABI matches the ABI of the embedded staking SC
+func VerifyStakingABI(abiJSON []byte) error {
+	fingerprint, err := ABIFingerprint(abiJSON)
+	if err != nil {
+		return err
+	}
+
+	if fingerprint != StakingABIFingerprint {
+		return fmt.Errorf(
+			"%w, expected fingerprint %s, got %s",
+			ErrABIFingerprintMismatch,
+			StakingABIFingerprint,
+			fingerprint,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestABIFingerprint(t *testing.T) {
+	var (
+		compactABI   = `[{"type":"function","name":"stake","inputs":[]},{"type":"constructor","inputs":[]}]`
+		formattedABI = `[
+			{
+				"inputs": [],
+				"type": "constructor"
+			},
+			{
+				"inputs": [],
+				"name": "stake",
+				"type": "function"
+			}
+		]`
+		otherABI = `[{"type":"function","name":"unstake","inputs":[]},{"type":"constructor","inputs":[]}]`
+	)
+
+	compactFingerprint, err := ABIFingerprint([]byte(compactABI))
+	assert.NoError(t, err)
+
+	formattedFingerprint, err := ABIFingerprint([]byte(formattedABI))
+	assert.NoError(t, err)
+
+	otherFingerprint, err := ABIFingerprint([]byte(otherABI))
+	assert.NoError(t, err)
+
+	assert.Equal(t, compactFingerprint, formattedFingerprint)
+	assert.NotEqual(t, compactFingerprint, otherFingerprint)
+
+	_, err = ABIFingerprint([]byte("{"))
+	assert.Error(t, err)
+}
+
+func TestVerifyStakingABI(t *testing.T) {
+	assert.NoError(t, VerifyStakingABI([]byte(abis.StakingJSONABI)))
+
+	assert.ErrorIs(t, VerifyStakingABI([]byte(abis.StressTestJSONABI)), ErrABIFingerprintMismatch)
+}
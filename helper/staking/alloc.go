@@ -0,0 +1,78 @@
+package staking
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrStakingAddressTaken  = errors.New("staking SC address already has code in the alloc")
+	ErrValidatorUnderfunded = errors.New("validator balance is lower than its committed stake")
+)
+
+// IsValidatorInAlloc checks if the address is a validator of the staking SC
+// predeployed in the genesis alloc. If the alloc has no staking SC account,
+// the address is not a validator
+func IsValidatorInAlloc(alloc map[types.Address]*chain.GenesisAccount, address types.Address) (bool, error) {
+	stakingAccount, ok := alloc[stakingContracts.AddrStakingContract]
+	if !ok || stakingAccount == nil {
+		return false, nil
+	}
+
+	return IsValidatorInStorage(stakingAccount.Storage, address)
+}
+
+// CheckStakingAddressFree checks that the staking SC address has no code in the alloc,
+// so merging the staking SC predeploy into it doesn't overwrite an already deployed contract
+func CheckStakingAddressFree(alloc map[types.Address]*chain.GenesisAccount) error {
+	account, ok := alloc[stakingContracts.AddrStakingContract]
+	if !ok || account == nil || len(account.Code) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w, address %s", ErrStakingAddressTaken, stakingContracts.AddrStakingContract)
+}
+
+// AssertValidatorsFunded checks that the balance of each validator in the alloc covers its committed stake,
+// for chains where the validators transfer their stakes to the staking SC themselves.
+// A validator without an account in the alloc has no balance
+func AssertValidatorsFunded(
+	alloc map[types.Address]*chain.GenesisAccount,
+	stakes map[types.Address]*big.Int,
+) error {
+	validators := make([]types.Address, 0, len(stakes))
+	for validator := range stakes {
+		validators = append(validators, validator)
+	}
+
+	// Sort the validators, so the error doesn't depend on the map iteration order
+	sort.Slice(validators, func(i, j int) bool {
+		return bytes.Compare(validators[i].Bytes(), validators[j].Bytes()) < 0
+	})
+
+	underfunded := make([]string, 0)
+
+	for _, validator := range validators {
+		balance := big.NewInt(0)
+		if account, ok := alloc[validator]; ok && account != nil {
+			balance = accountBalance(account)
+		}
+
+		if stake := stakes[validator]; balance.Cmp(stake) < 0 {
+			underfunded = append(underfunded, fmt.Sprintf("%s (balance %s, stake %s)", validator, balance, stake))
+		}
+	}
+
+	if len(underfunded) > 0 {
+		return fmt.Errorf("%w, validators %v", ErrValidatorUnderfunded, underfunded)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidatorInAlloc(t *testing.T) {
+	stakingAccount, err := PredeployStakingSC(
+		[]types.Address{addr1},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		alloc    map[types.Address]*chain.GenesisAccount
+		address  types.Address
+		expected bool
+	}{
+		{
+			name: "validator is present",
+			alloc: map[types.Address]*chain.GenesisAccount{
+				stakingContracts.AddrStakingContract: stakingAccount,
+			},
+			address:  addr1,
+			expected: true,
+		},
+		{
+			name: "validator is absent",
+			alloc: map[types.Address]*chain.GenesisAccount{
+				stakingContracts.AddrStakingContract: stakingAccount,
+			},
+			address:  addr2,
+			expected: false,
+		},
+		{
+			name: "staking account is missing",
+			alloc: map[types.Address]*chain.GenesisAccount{
+				addr2: {Balance: stakingAccount.Balance},
+			},
+			address:  addr1,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isValidator, err := IsValidatorInAlloc(tt.alloc, tt.address)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, isValidator)
+		})
+	}
+}
+
+func TestCheckStakingAddressFree(t *testing.T) {
+	stakingAccount, err := PredeployStakingSC(
+		[]types.Address{addr1},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		alloc   map[types.Address]*chain.GenesisAccount
+		succeed bool
+	}{
+		{
+			name: "address is not in the alloc",
+			alloc: map[types.Address]*chain.GenesisAccount{
+				addr1: {Balance: big.NewInt(1)},
+			},
+			succeed: true,
+		},
+		{
+			name: "address has only a balance",
+			alloc: map[types.Address]*chain.GenesisAccount{
+				stakingContracts.AddrStakingContract: {Balance: big.NewInt(1)},
+			},
+			succeed: true,
+		},
+		{
+			name: "address has code",
+			alloc: map[types.Address]*chain.GenesisAccount{
+				stakingContracts.AddrStakingContract: stakingAccount,
+			},
+			succeed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckStakingAddressFree(tt.alloc)
+			if tt.succeed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrStakingAddressTaken)
+			}
+		})
+	}
+}
+
+func TestAssertValidatorsFunded(t *testing.T) {
+	stake := big.NewInt(1e18)
+	stakes := map[types.Address]*big.Int{
+		addr1: stake,
+		addr2: stake,
+	}
+
+	t.Run("should accept funded validators", func(t *testing.T) {
+		alloc := map[types.Address]*chain.GenesisAccount{
+			addr1: {Balance: stake},
+			addr2: {Balance: new(big.Int).Add(stake, big.NewInt(1))},
+		}
+
+		assert.NoError(t, AssertValidatorsFunded(alloc, stakes))
+	})
+
+	t.Run("should reject underfunded validators", func(t *testing.T) {
+		alloc := map[types.Address]*chain.GenesisAccount{
+			addr1: {Balance: new(big.Int).Sub(stake, big.NewInt(1))},
+		}
+
+		err := AssertValidatorsFunded(alloc, stakes)
+		assert.ErrorIs(t, err, ErrValidatorUnderfunded)
+
+		// Both the underfunded validator and the validator missing from the alloc are reported
+		assert.Contains(t, err.Error(), addr1.String())
+		assert.Contains(t, err.Error(), addr2.String())
+	})
+}
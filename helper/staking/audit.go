@@ -0,0 +1,76 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrStorageKeyCollision = errors.New("storage key is written with different values")
+)
+
+// StorageWrite is a single write to the staking SC account storage
+type StorageWrite struct {
+	Key   types.Hash
+	Value types.Hash
+}
+
+// AuditKeyCollisions checks that no two writes set the same storage key to different values,
+// which happens when different slot encodings unexpectedly end up with the same key
+func AuditKeyCollisions(writes []StorageWrite) error {
+	written := make(map[types.Hash]types.Hash, len(writes))
+
+	for _, write := range writes {
+		if value, ok := written[write.Key]; ok && value != write.Value {
+			return fmt.Errorf(
+				"%w, key %s, values %s and %s",
+				ErrStorageKeyCollision,
+				write.Key,
+				value,
+				write.Value,
+			)
+		}
+
+		written[write.Key] = write.Value
+	}
+
+	return nil
+}
+
+// PredeployStakingSCAudited is PredeployStakingSC, which additionally records every storage write
+// made while generating the account, and audits them for key collisions
+func PredeployStakingSCAudited(
+	validators []types.Address,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	stakedValidators, err := defaultStakedValidators(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	return predeployStakingSCAudited(defaultStakingSCBuild, stakedValidators, params)
+}
+
+func predeployStakingSCAudited(
+	build StakingSCBuild,
+	validators []stakedValidator,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	writes := make([]StorageWrite, 0, StorageEntryCount(len(validators)))
+
+	stakingAccount, err := predeployStakingSCWithHook(build, validators, params, func(write StorageWrite) {
+		writes = append(writes, write)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := AuditKeyCollisions(writes); err != nil {
+		return nil, err
+	}
+
+	return stakingAccount, nil
+}
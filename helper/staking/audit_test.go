@@ -0,0 +1,75 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditKeyCollisions(t *testing.T) {
+	key1 := types.StringToHash("1")
+	key2 := types.StringToHash("2")
+
+	tests := []struct {
+		name    string
+		writes  []StorageWrite
+		succeed bool
+	}{
+		{
+			"unique keys",
+			[]StorageWrite{{key1, types.StringToHash("a")}, {key2, types.StringToHash("b")}},
+			true,
+		},
+		{
+			"same key with the same value",
+			[]StorageWrite{{key1, types.StringToHash("a")}, {key1, types.StringToHash("a")}},
+			true,
+		},
+		{
+			"same key with different values",
+			[]StorageWrite{{key1, types.StringToHash("a")}, {key1, types.StringToHash("b")}},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AuditKeyCollisions(tt.writes)
+			if tt.succeed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrStorageKeyCollision)
+			}
+		})
+	}
+}
+
+func TestPredeployStakingSCAudited(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	t.Run("should match the unaudited predeploy", func(t *testing.T) {
+		validators := []types.Address{addr1, addr2}
+
+		account, err := PredeployStakingSCAudited(validators, params)
+		assert.NoError(t, err)
+
+		expected, err := PredeployStakingSC(validators, params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, account)
+	})
+
+	t.Run("should detect colliding slots", func(t *testing.T) {
+		// The validator bounds share the same slot
+		build := defaultStakingSCBuild
+		build.Slots.MaxNumValidator = build.Slots.MinNumValidator
+
+		stakedValidators, err := defaultStakedValidators([]types.Address{addr1})
+		assert.NoError(t, err)
+
+		// The layout is checked before anything is written, so the writes are never audited
+		_, err = predeployStakingSCAudited(build, stakedValidators, params)
+		assert.ErrorIs(t, err, ErrSlotCollision)
+	})
+}
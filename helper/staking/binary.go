@@ -0,0 +1,149 @@
+package staking
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrInvalidBinaryAccount = errors.New("invalid binary encoded genesis account")
+
+// MarshalBinary encodes the genesis account in a compact binary form, which is smaller
+// and faster to decode than JSON for large validator sets. The encoding is:
+//
+//	uint64 code length | code | uint64 balance length | balance | uint64 nonce |
+//	uint64 storage entry count | (32 byte key | 32 byte value) entries in ascending key order
+//
+// with all the integers big endian
+func MarshalBinary(account *chain.GenesisAccount) ([]byte, error) {
+	balance := accountBalance(account)
+	if balance.Sign() < 0 {
+		return nil, fmt.Errorf("%w, balance %s", ErrNegativeBalance, balance)
+	}
+
+	balanceBytes := balance.Bytes()
+
+	buf := make([]byte, 0, 4*8+len(account.Code)+len(balanceBytes)+len(account.Storage)*2*types.HashLength)
+
+	buf = appendUint64(buf, uint64(len(account.Code)))
+	buf = append(buf, account.Code...)
+
+	buf = appendUint64(buf, uint64(len(balanceBytes)))
+	buf = append(buf, balanceBytes...)
+
+	buf = appendUint64(buf, account.Nonce)
+
+	// Sort the storage keys, so the encoding doesn't depend on the map iteration order
+	buf = appendUint64(buf, uint64(len(account.Storage)))
+	for _, key := range sortedStorageKeys(account.Storage) {
+		value := account.Storage[key]
+
+		buf = append(buf, key.Bytes()...)
+		buf = append(buf, value.Bytes()...)
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary decodes the genesis account encoded by MarshalBinary
+func UnmarshalBinary(data []byte) (*chain.GenesisAccount, error) {
+	reader := bytes.NewReader(data)
+
+	code, err := readLengthPrefixed(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w, code: %v", ErrInvalidBinaryAccount, err)
+	}
+
+	balance, err := readLengthPrefixed(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w, balance: %v", ErrInvalidBinaryAccount, err)
+	}
+
+	nonce, err := readUint64(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w, nonce: %v", ErrInvalidBinaryAccount, err)
+	}
+
+	count, err := readUint64(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w, storage entry count: %v", ErrInvalidBinaryAccount, err)
+	}
+
+	// Each storage entry is a key and a value
+	entrySize := 2 * types.HashLength
+	if reader.Len()%entrySize != 0 || count != uint64(reader.Len()/entrySize) {
+		return nil, fmt.Errorf(
+			"%w, %d storage entries don't match the %d remaining bytes",
+			ErrInvalidBinaryAccount,
+			count,
+			reader.Len(),
+		)
+	}
+
+	storage := make(map[types.Hash]types.Hash, count)
+
+	for i := uint64(0); i < count; i++ {
+		var key, value types.Hash
+
+		// The remaining length is checked above, so the reads can't fail
+		_, _ = io.ReadFull(reader, key[:])
+		_, _ = io.ReadFull(reader, value[:])
+
+		storage[key] = value
+	}
+
+	account := &chain.GenesisAccount{
+		Balance: new(big.Int).SetBytes(balance),
+		Nonce:   nonce,
+		Storage: storage,
+	}
+
+	if len(code) != 0 {
+		account.Code = code
+	}
+
+	return account, nil
+}
+
+// appendUint64 appends the big endian encoding of the value
+func appendUint64(buf []byte, value uint64) []byte {
+	var valueBuf [8]byte
+
+	binary.BigEndian.PutUint64(valueBuf[:], value)
+
+	return append(buf, valueBuf[:]...)
+}
+
+// readUint64 reads a big endian encoded value
+func readUint64(reader *bytes.Reader) (uint64, error) {
+	var valueBuf [8]byte
+
+	if _, err := io.ReadFull(reader, valueBuf[:]); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(valueBuf[:]), nil
+}
+
+// readLengthPrefixed reads a byte slice prefixed with its big endian encoded length
+func readLengthPrefixed(reader *bytes.Reader) ([]byte, error) {
+	length, err := readUint64(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if length > uint64(reader.Len()) {
+		return nil, fmt.Errorf("length %d exceeds the %d remaining bytes", length, reader.Len())
+	}
+
+	value := make([]byte, length)
+	_, _ = io.ReadFull(reader, value)
+
+	return value, nil
+}
@@ -0,0 +1,76 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	stakingAccount, err := PredeployStakingSC(
+		[]types.Address{addr1, addr2},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		account *chain.GenesisAccount
+	}{
+		{"staking SC account", stakingAccount},
+		{
+			"empty storage account",
+			&chain.GenesisAccount{
+				Code:    []byte{0x60, 0x80},
+				Balance: big.NewInt(1000),
+				Nonce:   1,
+				Storage: map[types.Hash]types.Hash{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := MarshalBinary(tt.account)
+			assert.NoError(t, err)
+
+			decoded, err := UnmarshalBinary(data)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.account, decoded)
+		})
+	}
+
+	t.Run("should be deterministic", func(t *testing.T) {
+		data, err := MarshalBinary(stakingAccount)
+		assert.NoError(t, err)
+
+		for i := 0; i < 10; i++ {
+			otherData, err := MarshalBinary(stakingAccount)
+			assert.NoError(t, err)
+
+			assert.Equal(t, data, otherData)
+		}
+	})
+
+	t.Run("should reject truncated data", func(t *testing.T) {
+		data, err := MarshalBinary(stakingAccount)
+		assert.NoError(t, err)
+
+		for _, length := range []int{0, 4, 12, len(data) - 1} {
+			_, err := UnmarshalBinary(data[:length])
+			assert.ErrorIs(t, err, ErrInvalidBinaryAccount)
+		}
+	})
+
+	t.Run("should reject trailing data", func(t *testing.T) {
+		data, err := MarshalBinary(stakingAccount)
+		assert.NoError(t, err)
+
+		_, err = UnmarshalBinary(append(data, make([]byte, 2*types.HashLength)...))
+		assert.ErrorIs(t, err, ErrInvalidBinaryAccount)
+	})
+}
@@ -0,0 +1,88 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrInvalidValidatorBound = errors.New("validator bound doesn't fit in uint64")
+	ErrBoundOutOfLimits      = errors.New("validator bound is outside the absolute validator count limits")
+)
+
+// SetValidatorBounds writes the minimum and maximum number of validators
+// to the staking SC storage, encoded as uint256 words
+func SetValidatorBounds(storage map[types.Hash]types.Hash, minCount, maxCount uint64) {
+	DefaultStorageSlots.setValidatorBounds(
+		func(key, value types.Hash) {
+			storage[key] = value
+		},
+		minCount,
+		maxCount,
+	)
+}
+
+// GetValidatorBounds reads the minimum and maximum number of validators
+// from the staking SC storage
+func GetValidatorBounds(storage map[types.Hash]types.Hash) (uint64, uint64, error) {
+	return DefaultStorageSlots.getValidatorBounds(storage)
+}
+
+// AssertBoundsWithinLimits checks that the minimum and maximum number of validators
+// are within the absolute MinValidatorCount and MaxValidatorCount limits
+func AssertBoundsWithinLimits(params PredeployParams) error {
+	if params.MinValidatorCount < MinValidatorCount {
+		return fmt.Errorf(
+			"%w, minimum %d is lower than %d",
+			ErrBoundOutOfLimits,
+			params.MinValidatorCount,
+			MinValidatorCount,
+		)
+	}
+
+	if params.MaxValidatorCount > MaxValidatorCount {
+		return fmt.Errorf(
+			"%w, maximum %d is greater than %d",
+			ErrBoundOutOfLimits,
+			params.MaxValidatorCount,
+			MaxValidatorCount,
+		)
+	}
+
+	return nil
+}
+
+func (s StorageSlots) setValidatorBounds(setStorage func(key, value types.Hash), minCount, maxCount uint64) {
+	// Set the value for the minimum number of validators
+	setStorage(SlotKey(s.MinNumValidator), types.BytesToHash(new(big.Int).SetUint64(minCount).Bytes()))
+
+	// Set the value for the maximum number of validators
+	setStorage(SlotKey(s.MaxNumValidator), types.BytesToHash(new(big.Int).SetUint64(maxCount).Bytes()))
+}
+
+func (s StorageSlots) getValidatorBounds(storage map[types.Hash]types.Hash) (uint64, uint64, error) {
+	minCount, err := getUint64Slot(storage, s.MinNumValidator)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to read the minimum number of validators, %w", err)
+	}
+
+	maxCount, err := getUint64Slot(storage, s.MaxNumValidator)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to read the maximum number of validators, %w", err)
+	}
+
+	return minCount, maxCount, nil
+}
+
+// getUint64Slot reads the uint256 value of a plain storage slot that is expected to fit in uint64
+func getUint64Slot(storage map[types.Hash]types.Hash, slot int64) (uint64, error) {
+	value := new(big.Int).SetBytes(storage[SlotKey(slot)].Bytes())
+	if !value.IsUint64() {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidValidatorBound, value)
+	}
+
+	return value.Uint64(), nil
+}
@@ -0,0 +1,81 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatorBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		minCount uint64
+		maxCount uint64
+	}{
+		{"default bounds", MinValidatorCount, MaxValidatorCount},
+		{"custom bounds", 4, 100},
+		{"full uint64 range", 0, ^uint64(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := make(map[types.Hash]types.Hash)
+
+			SetValidatorBounds(storage, tt.minCount, tt.maxCount)
+
+			minCount, maxCount, err := GetValidatorBounds(storage)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.minCount, minCount)
+			assert.Equal(t, tt.maxCount, maxCount)
+		})
+	}
+
+	t.Run("should reject a bound that doesn't fit in uint64", func(t *testing.T) {
+		storage := make(map[types.Hash]types.Hash)
+
+		SetValidatorBounds(storage, 1, 1)
+		storage[types.BytesToHash(big.NewInt(maxNumValidatorSlot).Bytes())] = types.StringToHash("0x010000000000000000")
+
+		_, _, err := GetValidatorBounds(storage)
+		assert.ErrorIs(t, err, ErrInvalidValidatorBound)
+	})
+
+	t.Run("should match the predeployed bounds", func(t *testing.T) {
+		account, err := PredeployStakingSC(nil, PredeployParams{MinValidatorCount: 4, MaxValidatorCount: 100})
+		assert.NoError(t, err)
+
+		minCount, maxCount, err := GetValidatorBounds(account.Storage)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(4), minCount)
+		assert.Equal(t, uint64(100), maxCount)
+	})
+}
+
+func TestAssertBoundsWithinLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		minCount uint64
+		maxCount uint64
+		succeed  bool
+	}{
+		{"bounds at the limits", MinValidatorCount, MaxValidatorCount, true},
+		{"minimum below the limit", MinValidatorCount - 1, MaxValidatorCount, false},
+		{"maximum above the limit", MinValidatorCount, MaxValidatorCount + 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AssertBoundsWithinLimits(PredeployParams{
+				MinValidatorCount: tt.minCount,
+				MaxValidatorCount: tt.maxCount,
+			})
+			if tt.succeed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrBoundOutOfLimits)
+			}
+		})
+	}
+}
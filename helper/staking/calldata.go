@@ -0,0 +1,44 @@
+package staking
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo/abi"
+)
+
+var (
+	ErrValidatorsMethodNotFound = errors.New("validators method doesn't exist in the staking SC ABI")
+)
+
+// validatorsMethod returns the validators() view of the embedded staking SC ABI
+func validatorsMethod() (*abi.Method, error) {
+	method, ok := abis.StakingABI.Methods["validators"]
+	if !ok {
+		return nil, ErrValidatorsMethodNotFound
+	}
+
+	return method, nil
+}
+
+// EncodeValidatorsCalldata returns the calldata for calling the validators() view of the staking SC
+func EncodeValidatorsCalldata() ([]byte, error) {
+	method, err := validatorsMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	return method.ID(), nil
+}
+
+// DecodeValidatorsOutput decodes the ABI encoded address[] returned by the validators() view of the staking SC
+func DecodeValidatorsOutput(data []byte) ([]types.Address, error) {
+	method, err := validatorsMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	return stakingContracts.DecodeValidators(method, data)
+}
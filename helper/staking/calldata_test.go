@@ -0,0 +1,85 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeValidatorsCalldata(t *testing.T) {
+	calldata, err := EncodeValidatorsCalldata()
+	assert.NoError(t, err)
+
+	// keccak256("validators()")[:4]
+	assert.Equal(t, hex.MustDecodeHex("0xca1e7819"), calldata)
+}
+
+func TestDecodeValidatorsOutput(t *testing.T) {
+	word := func(value []byte) []byte {
+		return types.BytesToHash(value).Bytes()
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		succeed  bool
+		expected []types.Address
+	}{
+		{
+			name: "empty array",
+			data: appendWords(
+				word(big.NewInt(0x20).Bytes()), // Offset of the beginning of array
+				word(big.NewInt(0).Bytes()),    // Number of addresses
+			),
+			succeed:  true,
+			expected: []types.Address{},
+		},
+		{
+			name: "two addresses",
+			data: appendWords(
+				word(big.NewInt(0x20).Bytes()), // Offset of the beginning of array
+				word(big.NewInt(2).Bytes()),    // Number of addresses
+				word(addr1.Bytes()),
+				word(addr2.Bytes()),
+			),
+			succeed:  true,
+			expected: []types.Address{addr1, addr2},
+		},
+		{
+			name: "truncated array",
+			data: appendWords(
+				word(big.NewInt(0x20).Bytes()), // Offset of the beginning of array
+				word(big.NewInt(2).Bytes()),    // Number of addresses
+				word(addr1.Bytes()),
+			),
+			succeed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validators, err := DecodeValidatorsOutput(tt.data)
+			if !tt.succeed {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, validators)
+		})
+	}
+}
+
+func appendWords(words ...[]byte) []byte {
+	data := make([]byte, 0, len(words)*types.HashLength)
+
+	for _, word := range words {
+		data = append(data, word...)
+	}
+
+	return data
+}
@@ -0,0 +1,218 @@
+package staking
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+var (
+	ErrChainParamsMismatch = errors.New("staking SC account doesn't match the chain params")
+	ErrNoGenesisIBFTFork   = errors.New("chain params have no IBFT mechanism at genesis")
+)
+
+const (
+	ibftEngine = "ibft"
+
+	// ibftDefaultEpochSize is the epoch size the IBFT consensus uses when the engine config doesn't set one
+	ibftDefaultEpochSize = 100000
+
+	// IBFT mechanism types
+	ibftPoA = "PoA"
+	ibftPoS = "PoS"
+)
+
+// ibftConfig is the IBFT engine config in params.engine.ibft
+type ibftConfig struct {
+	EpochSize *common.JSONNumber `json:"epochSize,omitempty"`
+}
+
+// ibftForkConfig is the IBFT mechanism config in params.engine.ibft.
+// It mirrors the IBFT consensus fork config, which can't be imported since the consensus imports this package
+type ibftForkConfig struct {
+	Type              string             `json:"type"`
+	From              common.JSONNumber  `json:"from"`
+	MaxValidatorCount *common.JSONNumber `json:"maxValidatorCount,omitempty"`
+	MinValidatorCount *common.JSONNumber `json:"minValidatorCount,omitempty"`
+}
+
+// AssertMatchesChainParams checks that the staking SC account of the build agrees with the IBFT config
+// the chain params start with, and with the validators of the genesis extra data:
+//
+//   - the IBFT epoch size must be greater than zero, and match the epoch size the build records (if any)
+//   - a PoS chain needs the predeployed validators, within the validator bounds of the mechanism config (if set),
+//     and they must be the same set as the genesis extra data validators
+//   - a PoA chain can have the staking SC predeployed for switching to PoS later,
+//     but with no validators registered, since the PoA validators are not derived from the stakes
+func AssertMatchesChainParams(
+	build StakingSCBuild,
+	account *chain.GenesisAccount,
+	params *chain.Params,
+	extraData []byte,
+) error {
+	fork, err := genesisIBFTFork(params)
+	if err != nil {
+		return err
+	}
+
+	if err := assertMatchesEpochSize(build, account, params); err != nil {
+		return err
+	}
+
+	validators, err := DecodeValidators(account)
+	if err != nil {
+		return err
+	}
+
+	if fork.Type == ibftPoA {
+		if len(validators) != 0 {
+			return fmt.Errorf(
+				"%w, %d validators are registered, but the chain starts with PoA",
+				ErrChainParamsMismatch,
+				len(validators),
+			)
+		}
+
+		return nil
+	}
+
+	if fork.Type != ibftPoS {
+		return fmt.Errorf("%w, unknown IBFT type %q", ErrChainParamsMismatch, fork.Type)
+	}
+
+	minCount, maxCount, err := build.Slots.getValidatorBounds(account.Storage)
+	if err != nil {
+		return err
+	}
+
+	if fork.MinValidatorCount != nil && fork.MinValidatorCount.Value != minCount {
+		return fmt.Errorf(
+			"%w, minimum number of validators is %d, chain params minimum is %d",
+			ErrChainParamsMismatch,
+			minCount,
+			fork.MinValidatorCount.Value,
+		)
+	}
+
+	if fork.MaxValidatorCount != nil && fork.MaxValidatorCount.Value != maxCount {
+		return fmt.Errorf(
+			"%w, maximum number of validators is %d, chain params maximum is %d",
+			ErrChainParamsMismatch,
+			maxCount,
+			fork.MaxValidatorCount.Value,
+		)
+	}
+
+	if numValidators := uint64(len(validators)); numValidators == 0 || numValidators < minCount ||
+		numValidators > maxCount {
+		return fmt.Errorf(
+			"%w, %d validators are registered, the PoS chain needs between %d and %d",
+			ErrChainParamsMismatch,
+			numValidators,
+			minCount,
+			maxCount,
+		)
+	}
+
+	extraValidators, err := ValidatorsFromIBFTExtra(extraData)
+	if err != nil {
+		return err
+	}
+
+	if err := CrossCheckWithSnapshot(account, extraValidators); err != nil {
+		return fmt.Errorf("%w, genesis extra data validators differ, %v", ErrChainParamsMismatch, err)
+	}
+
+	return nil
+}
+
+// assertMatchesEpochSize checks that the IBFT epoch size is valid,
+// and matches the epoch size recorded in the staking SC, if the build records it
+func assertMatchesEpochSize(build StakingSCBuild, account *chain.GenesisAccount, params *chain.Params) error {
+	epochSize, err := ibftEpochSize(params)
+	if err != nil {
+		return err
+	}
+
+	if epochSize == 0 {
+		return fmt.Errorf("%w, IBFT epoch size is zero", ErrChainParamsMismatch)
+	}
+
+	if build.Slots.EpochSize == nil {
+		return nil
+	}
+
+	stakingEpochSize := new(big.Int).SetBytes(account.Storage[SlotKey(*build.Slots.EpochSize)].Bytes())
+	if !stakingEpochSize.IsUint64() || stakingEpochSize.Uint64() != epochSize {
+		return fmt.Errorf(
+			"%w, epoch size is %s, chain params epoch size is %d",
+			ErrChainParamsMismatch,
+			stakingEpochSize,
+			epochSize,
+		)
+	}
+
+	return nil
+}
+
+// ibftEpochSize returns the epoch size of the IBFT engine config,
+// or the IBFT default if the config doesn't set it
+func ibftEpochSize(params *chain.Params) (uint64, error) {
+	// Decode the config the same way the forks are decoded, since in-memory params can hold any number type
+	data, err := json.Marshal(params.Engine[ibftEngine])
+	if err != nil {
+		return 0, err
+	}
+
+	var config ibftConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return 0, fmt.Errorf("invalid IBFT config, %w", err)
+	}
+
+	if config.EpochSize == nil {
+		return ibftDefaultEpochSize, nil
+	}
+
+	return config.EpochSize.Value, nil
+}
+
+// genesisIBFTFork returns the IBFT mechanism config active at genesis,
+// set either as a single type or as a list of typed forks
+func genesisIBFTFork(params *chain.Params) (*ibftForkConfig, error) {
+	ibftConfig, ok := params.Engine[ibftEngine].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w, engine is %q", ErrNoGenesisIBFTFork, params.GetEngine())
+	}
+
+	if typ, ok := ibftConfig["type"].(string); ok {
+		return &ibftForkConfig{Type: typ}, nil
+	}
+
+	rawForks, ok := ibftConfig["types"]
+	if !ok {
+		return nil, ErrNoGenesisIBFTFork
+	}
+
+	// Decode the forks the same way the consensus does
+	data, err := json.Marshal(rawForks)
+	if err != nil {
+		return nil, err
+	}
+
+	var forks []ibftForkConfig
+	if err := json.Unmarshal(data, &forks); err != nil {
+		return nil, fmt.Errorf("invalid IBFT forks, %w", err)
+	}
+
+	for indx := range forks {
+		if forks[indx].From.Value == 0 {
+			return &forks[indx], nil
+		}
+	}
+
+	return nil, ErrNoGenesisIBFTFork
+}
@@ -0,0 +1,191 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertMatchesChainParams(t *testing.T) {
+	posAccount, err := PredeployStakingSC(
+		[]types.Address{addr1, addr2},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	poaAccount, err := PredeployStakingSC(
+		[]types.Address{addr1, addr2},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10, DisableStaking: true},
+	)
+	assert.NoError(t, err)
+
+	epochSizeSlot := int64(7)
+	epochBuild := defaultStakingSCBuild
+	epochBuild.Slots.EpochSize = &epochSizeSlot
+
+	stakedValidators, err := defaultStakedValidators([]types.Address{addr1, addr2})
+	assert.NoError(t, err)
+
+	epochAccount, err := predeployStakingSC(epochBuild, stakedValidators, PredeployParams{
+		MinValidatorCount: 1,
+		MaxValidatorCount: 10,
+		EpochSize:         10,
+	})
+	assert.NoError(t, err)
+
+	posExtra, err := ValidatorsToIBFTExtra(posAccount)
+	assert.NoError(t, err)
+
+	otherAccount, err := PredeployStakingSC(
+		[]types.Address{addr1},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	otherExtra, err := ValidatorsToIBFTExtra(otherAccount)
+	assert.NoError(t, err)
+
+	ibftParams := func(config map[string]interface{}) *chain.Params {
+		return &chain.Params{
+			Engine: map[string]interface{}{
+				"ibft": config,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		build   StakingSCBuild
+		account *chain.GenesisAccount
+		params  *chain.Params
+		extra   []byte
+		err     error
+	}{
+		{
+			"PoS chain",
+			defaultStakingSCBuild,
+			posAccount,
+			ibftParams(map[string]interface{}{"type": "PoS", "epochSize": 10}),
+			posExtra,
+			nil,
+		},
+		{
+			"PoS fork with matching bounds",
+			defaultStakingSCBuild,
+			posAccount,
+			ibftParams(map[string]interface{}{"types": []interface{}{
+				map[string]interface{}{"type": "PoS", "from": "0x0", "minValidatorCount": 1, "maxValidatorCount": 10},
+			}}),
+			posExtra,
+			nil,
+		},
+		{
+			"PoA chain with staking disabled",
+			defaultStakingSCBuild,
+			poaAccount,
+			ibftParams(map[string]interface{}{"type": "PoA"}),
+			posExtra,
+			nil,
+		},
+		{
+			"PoA chain with registered validators",
+			defaultStakingSCBuild,
+			posAccount,
+			ibftParams(map[string]interface{}{"type": "PoA"}),
+			posExtra,
+			ErrChainParamsMismatch,
+		},
+		{
+			"PoS chain without validators",
+			defaultStakingSCBuild,
+			poaAccount,
+			ibftParams(map[string]interface{}{"type": "PoS"}),
+			posExtra,
+			ErrChainParamsMismatch,
+		},
+		{
+			"PoS fork with a different maximum",
+			defaultStakingSCBuild,
+			posAccount,
+			ibftParams(map[string]interface{}{"types": []interface{}{
+				map[string]interface{}{"type": "PoS", "from": "0x0", "maxValidatorCount": 20},
+			}}),
+			posExtra,
+			ErrChainParamsMismatch,
+		},
+		{
+			"PoS fork after genesis",
+			defaultStakingSCBuild,
+			posAccount,
+			ibftParams(map[string]interface{}{"types": []interface{}{
+				map[string]interface{}{"type": "PoS", "from": "0x10"},
+			}}),
+			posExtra,
+			ErrNoGenesisIBFTFork,
+		},
+		{
+			"PoS chain with different extra data validators",
+			defaultStakingSCBuild,
+			posAccount,
+			ibftParams(map[string]interface{}{"type": "PoS"}),
+			otherExtra,
+			ErrChainParamsMismatch,
+		},
+		{
+			"PoS chain with invalid extra data",
+			defaultStakingSCBuild,
+			posAccount,
+			ibftParams(map[string]interface{}{"type": "PoS"}),
+			make([]byte, ibftExtraVanity),
+			ErrInvalidIBFTExtra,
+		},
+		{
+			"zero epoch size",
+			defaultStakingSCBuild,
+			posAccount,
+			ibftParams(map[string]interface{}{"type": "PoS", "epochSize": 0}),
+			posExtra,
+			ErrChainParamsMismatch,
+		},
+		{
+			"matching recorded epoch size",
+			epochBuild,
+			epochAccount,
+			ibftParams(map[string]interface{}{"type": "PoS", "epochSize": 10}),
+			posExtra,
+			nil,
+		},
+		{
+			"different recorded epoch size",
+			epochBuild,
+			epochAccount,
+			ibftParams(map[string]interface{}{"type": "PoS", "epochSize": 20}),
+			posExtra,
+			ErrChainParamsMismatch,
+		},
+		{
+			"recorded epoch size with the default epoch size",
+			epochBuild,
+			epochAccount,
+			ibftParams(map[string]interface{}{"type": "PoS"}),
+			posExtra,
+			ErrChainParamsMismatch,
+		},
+		{
+			"different engine",
+			defaultStakingSCBuild,
+			posAccount,
+			&chain.Params{Engine: map[string]interface{}{"dev": map[string]interface{}{}}},
+			posExtra,
+			ErrNoGenesisIBFTFork,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, AssertMatchesChainParams(tt.build, tt.account, tt.params, tt.extra), tt.err)
+		})
+	}
+}
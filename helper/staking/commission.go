@@ -0,0 +1,104 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// MaxCommission is the commission rate of 100%, in basis points
+const MaxCommission = 10000
+
+var (
+	ErrCommissionNotSupported = errors.New("staking SC doesn't support commissions")
+	ErrCommissionOutOfRange   = errors.New("validator commission is out of range")
+	ErrCommissionAboveCap     = errors.New("validator commission exceeds the commission cap")
+)
+
+// ValidatorCommission is a validator with the commission rate it charges its delegators,
+// in basis points (1/100 of a percent)
+type ValidatorCommission struct {
+	Address    types.Address
+	Commission uint16
+}
+
+// PredeployStakingSCWithCommissions is a helper method for setting up the staking smart contract account
+// of the given build, using the passed in validators as pre-staked validators.
+// The commission rate of each registered validator, which must be in the range [0, MaxCommission], is written to
+// the AddressToCommission mapping. The commission is a rate, so it doesn't change the staking SC balance.
+// The build must support delegation commissions (ex. a build registered with RegisterStakingSCBuild)
+func PredeployStakingSCWithCommissions(
+	build StakingSCBuild,
+	validators []ValidatorCommission,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	addresses := make([]types.Address, len(validators))
+	commissions := make(map[types.Address]types.Hash, len(validators))
+
+	for indx, validator := range validators {
+		if validator.Commission > MaxCommission {
+			return nil, fmt.Errorf(
+				"%w, validator %s, commission %d, max commission %d",
+				ErrCommissionOutOfRange,
+				validator.Address,
+				validator.Commission,
+				MaxCommission,
+			)
+		}
+
+		addresses[indx] = validator.Address
+		commissions[validator.Address] = types.BytesToHash(
+			new(big.Int).SetUint64(uint64(validator.Commission)).Bytes(),
+		)
+	}
+
+	stakedValidators, err := defaultStakedValidators(addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	return predeployStakingSCWithAddressMapping(
+		build,
+		stakedValidators,
+		params,
+		build.Slots.AddressToCommission,
+		ErrCommissionNotSupported,
+		commissions,
+	)
+}
+
+// AssertCommissionWithinCap checks that the commission rate of each of the passed in validators,
+// decoded from the AddressToCommission mapping of the staking SC storage with the given slots,
+// doesn't exceed capBps basis points. The slots must support delegation commissions
+func AssertCommissionWithinCap(
+	account *chain.GenesisAccount,
+	slots StorageSlots,
+	validators []types.Address,
+	capBps uint16,
+) error {
+	if slots.AddressToCommission == nil {
+		return ErrCommissionNotSupported
+	}
+
+	bigCap := new(big.Int).SetUint64(uint64(capBps))
+	overCap := make([]string, 0)
+
+	for _, validator := range validators {
+		commission := new(big.Int).SetBytes(
+			account.Storage[types.BytesToHash(getAddressMapping(validator, *slots.AddressToCommission))].Bytes(),
+		)
+
+		if commission.Cmp(bigCap) > 0 {
+			overCap = append(overCap, fmt.Sprintf("%s (%s)", validator, commission))
+		}
+	}
+
+	if len(overCap) > 0 {
+		return fmt.Errorf("%w, cap %d, validators %v", ErrCommissionAboveCap, capBps, overCap)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployStakingSCWithCommissions(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+	validators := []ValidatorCommission{
+		{Address: addr1, Commission: 1},
+		{Address: addr2, Commission: MaxCommission},
+	}
+
+	build := defaultStakingSCBuild
+	build.Slots.AddressToCommission = &testMappingSlot
+
+	t.Run("should write the commissions", func(t *testing.T) {
+		account, err := PredeployStakingSCWithCommissions(build, validators, params)
+		assert.NoError(t, err)
+
+		for _, validator := range validators {
+			assert.Equal(
+				t,
+				types.BytesToHash(big.NewInt(int64(validator.Commission)).Bytes()),
+				account.Storage[testMappingKeys[validator.Address]],
+			)
+		}
+
+		// The commissions don't change the staking SC balance
+		plainAccount, err := PredeployStakingSC([]types.Address{addr1, addr2}, params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, plainAccount.Balance, account.Balance)
+	})
+
+	t.Run("should not write the commissions when staking is disabled", func(t *testing.T) {
+		disabledParams := params
+		disabledParams.DisableStaking = true
+
+		account, err := PredeployStakingSCWithCommissions(build, validators, disabledParams)
+		assert.NoError(t, err)
+
+		for _, validator := range validators {
+			assert.NotContains(t, account.Storage, testMappingKeys[validator.Address])
+		}
+	})
+
+	t.Run("should reject a commission slot over a core slot", func(t *testing.T) {
+		collidingBuild := defaultStakingSCBuild
+		collidingSlot := stakedAmountSlot
+		collidingBuild.Slots.AddressToCommission = &collidingSlot
+
+		_, err := PredeployStakingSCWithCommissions(collidingBuild, validators, params)
+		assert.ErrorIs(t, err, ErrSlotCollision)
+	})
+
+	t.Run("should reject a commission above 100%", func(t *testing.T) {
+		_, err := PredeployStakingSCWithCommissions(
+			build,
+			[]ValidatorCommission{{Address: addr1, Commission: MaxCommission + 1}},
+			params,
+		)
+		assert.ErrorIs(t, err, ErrCommissionOutOfRange)
+	})
+
+	t.Run("should fail for the embedded staking SC", func(t *testing.T) {
+		_, err := PredeployStakingSCWithCommissions(defaultStakingSCBuild, validators, params)
+		assert.ErrorIs(t, err, ErrCommissionNotSupported)
+	})
+}
+
+func TestAssertCommissionWithinCap(t *testing.T) {
+	build := defaultStakingSCBuild
+	build.Slots.AddressToCommission = &testMappingSlot
+
+	account, err := PredeployStakingSCWithCommissions(
+		build,
+		[]ValidatorCommission{
+			{Address: addr1, Commission: 500},
+			{Address: addr2, Commission: 2000},
+		},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	validators := []types.Address{addr1, addr2}
+
+	testTable := []struct {
+		name        string
+		capBps      uint16
+		expectedErr error
+	}{
+		{"should pass when all the commissions are within the cap", 2000, nil},
+		{"should fail when a commission exceeds the cap", 1000, ErrCommissionAboveCap},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := AssertCommissionWithinCap(account, build.Slots, validators, testCase.capBps)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+
+	t.Run("should fail for the embedded staking SC slots", func(t *testing.T) {
+		err := AssertCommissionWithinCap(account, DefaultStorageSlots, validators, 2000)
+		assert.ErrorIs(t, err, ErrCommissionNotSupported)
+	})
+}
@@ -0,0 +1,141 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrInvalidValidatorsArraySize = errors.New("invalid validators array size")
+	ErrMissingValidator           = errors.New("missing validator in the validators array")
+	ErrInvalidBoolValue           = errors.New("invalid bool value in storage")
+)
+
+// DecodeValidators decodes the validators array from the staking SC account storage
+func DecodeValidators(account *chain.GenesisAccount) ([]types.Address, error) {
+	numValidators, err := decodeValidatorsArraySize(account)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]types.Address, numValidators)
+
+	for indx := range validators {
+		value, ok := account.Storage[types.BytesToHash(DefaultStorageSlots.validatorsArrayIndex(int64(indx)))]
+		if !ok {
+			return nil, fmt.Errorf("%w, index %d", ErrMissingValidator, indx)
+		}
+
+		validators[indx] = types.BytesToAddress(value.Bytes())
+	}
+
+	return validators, nil
+}
+
+// ValidatorSeq is a sequence of validators with their index in the validators array.
+// A decoding error is yielded with the index of the element that failed, and ends the sequence.
+// The iteration stops when yield returns false
+type ValidatorSeq func(yield func(int, types.Address, error) bool)
+
+// IterateValidators returns a sequence that lazily decodes the validators array
+// from the staking SC account storage, one element at a time.
+// The array size is validated upfront, and a missing element yields the same
+// ErrMissingValidator error as DecodeValidators
+func IterateValidators(account *chain.GenesisAccount) (ValidatorSeq, error) {
+	numValidators, err := decodeValidatorsArraySize(account)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(int, types.Address, error) bool) {
+		for indx := 0; indx < numValidators; indx++ {
+			value, ok := account.Storage[types.BytesToHash(DefaultStorageSlots.validatorsArrayIndex(int64(indx)))]
+			if !ok {
+				yield(indx, types.ZeroAddress, fmt.Errorf("%w, index %d", ErrMissingValidator, indx))
+
+				return
+			}
+
+			if !yield(indx, types.BytesToAddress(value.Bytes()), nil) {
+				return
+			}
+		}
+	}, nil
+}
+
+// decodeStakedValidators decodes the validators and their stakes from the staking SC account storage
+func decodeStakedValidators(account *chain.GenesisAccount) ([]stakedValidator, error) {
+	validators, err := DecodeValidators(account)
+	if err != nil {
+		return nil, err
+	}
+
+	stakedValidators := make([]stakedValidator, len(validators))
+
+	for indx, validator := range validators {
+		stakedValidators[indx] = stakedValidator{
+			address: validator,
+			stake: new(big.Int).SetBytes(
+				account.Storage[types.BytesToHash(getAddressMapping(validator, addressToStakedAmountSlot))].Bytes(),
+			),
+		}
+	}
+
+	return stakedValidators, nil
+}
+
+// decodeValidatorsArraySize decodes the size of the validators array
+// from the staking SC account storage.
+// Each array element is a storage entry, so a size over the number of storage entries is rejected
+// before the callers allocate for it (ex. a size slot of 2^52 in an untrusted state dump)
+func decodeValidatorsArraySize(account *chain.GenesisAccount) (int, error) {
+	size := new(big.Int).SetBytes(account.Storage[SlotKey(validatorsSlot)].Bytes())
+
+	if !size.IsUint64() || size.Uint64() > MaxValidatorCount {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidValidatorsArraySize, size)
+	}
+
+	if size.Uint64() > uint64(len(account.Storage)) {
+		return 0, fmt.Errorf(
+			"%w: %s, over the %d storage entries",
+			ErrInvalidValidatorsArraySize,
+			size,
+			len(account.Storage),
+		)
+	}
+
+	return int(size.Uint64()), nil
+}
+
+// IsValidatorInStorage checks if the address is marked as a validator
+// in the staking SC account storage
+func IsValidatorInStorage(storage map[types.Hash]types.Hash, address types.Address) (bool, error) {
+	value := storage[types.BytesToHash(getAddressMapping(address, addressToIsValidatorSlot))]
+
+	switch value {
+	case types.ZeroHash:
+		return false, nil
+	case types.BytesToHash(big.NewInt(1).Bytes()):
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w, validator %s value %s", ErrInvalidBoolValue, address, value)
+	}
+}
+
+// decodeTotalStakedAmount decodes the total staked amount from the staking SC account storage
+func decodeTotalStakedAmount(account *chain.GenesisAccount) *big.Int {
+	return new(big.Int).SetBytes(account.Storage[SlotKey(stakedAmountSlot)].Bytes())
+}
+
+// accountBalance returns the balance of the account, which is zero if not set
+func accountBalance(account *chain.GenesisAccount) *big.Int {
+	if account.Balance == nil {
+		return big.NewInt(0)
+	}
+
+	return account.Balance
+}
@@ -0,0 +1,103 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeValidators(t *testing.T) {
+	validators := []types.Address{addr1, addr2}
+
+	account, err := PredeployStakingSC(validators, PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10})
+	assert.NoError(t, err)
+
+	decoded, err := DecodeValidators(account)
+	assert.NoError(t, err)
+	assert.Equal(t, validators, decoded)
+
+	// Remove the last validator from the array, but not from the size slot
+	delete(account.Storage, types.BytesToHash(getStorageIndexes(addr2, 1).ValidatorsIndex))
+
+	_, err = DecodeValidators(account)
+	assert.ErrorIs(t, err, ErrMissingValidator)
+
+	// A size over the number of storage entries is rejected before allocating the array
+	account.Storage[SlotKey(validatorsSlot)] = types.BytesToHash(big.NewInt(1 << 52).Bytes())
+
+	_, err = DecodeValidators(account)
+	assert.ErrorIs(t, err, ErrInvalidValidatorsArraySize)
+
+	_, err = IterateValidators(account)
+	assert.ErrorIs(t, err, ErrInvalidValidatorsArraySize)
+}
+
+func TestIterateValidators(t *testing.T) {
+	validators := []types.Address{addr1, addr2, types.StringToAddress("3")}
+
+	account, err := PredeployStakingSC(validators, PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10})
+	assert.NoError(t, err)
+
+	seq, err := IterateValidators(account)
+	assert.NoError(t, err)
+
+	t.Run("should yield the validators in order", func(t *testing.T) {
+		iterated := make([]types.Address, 0)
+
+		seq(func(indx int, validator types.Address, err error) bool {
+			assert.NoError(t, err)
+			assert.Equal(t, len(iterated), indx)
+
+			iterated = append(iterated, validator)
+
+			return true
+		})
+
+		assert.Equal(t, validators, iterated)
+	})
+
+	t.Run("should stop when yield returns false", func(t *testing.T) {
+		count := 0
+
+		seq(func(int, types.Address, error) bool {
+			count++
+
+			return count < 2
+		})
+
+		assert.Equal(t, 2, count)
+	})
+	t.Run("should yield an error for a missing element", func(t *testing.T) {
+		// Remove the second validator from the array, but not from the size slot
+		delete(account.Storage, types.BytesToHash(getStorageIndexes(addr2, 1).ValidatorsIndex))
+
+		_, decodeErr := DecodeValidators(account)
+		assert.ErrorIs(t, decodeErr, ErrMissingValidator)
+
+		seq, err := IterateValidators(account)
+		assert.NoError(t, err)
+
+		iterated := make([]types.Address, 0)
+
+		var iterateErr error
+
+		seq(func(indx int, validator types.Address, err error) bool {
+			if err != nil {
+				assert.Equal(t, 1, indx)
+
+				iterateErr = err
+
+				return false
+			}
+
+			iterated = append(iterated, validator)
+
+			return true
+		})
+
+		assert.Equal(t, []types.Address{addr1}, iterated)
+		assert.Equal(t, decodeErr, iterateErr)
+	})
+}
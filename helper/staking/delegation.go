@@ -0,0 +1,158 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrDelegationsNotSupported = errors.New("staking SC doesn't support delegations")
+	ErrInvalidDelegationAmount = errors.New("delegation amount must be a positive amount")
+	ErrDelegationMismatch      = errors.New("delegated amounts don't sum up to the validator staked amount")
+)
+
+// Delegation is an amount staked by a delegator on behalf of a validator
+type Delegation struct {
+	Validator types.Address
+	Delegator types.Address
+	Amount    *big.Int
+}
+
+// getNestedAddressMapping returns the key for the SC storage mapping (address => mapping(address => something)),
+// calculated as keccak(inner . keccak(outer . slot))
+func getNestedAddressMapping(outer, inner types.Address, slot int64) []byte {
+	return Hasher(append(
+		types.BytesToHash(inner.Bytes()).Bytes(),
+		getAddressMapping(outer, slot)...,
+	))
+}
+
+// PredeployDelegatedStaking is a helper method for setting up the staking smart contract account
+// of the given build for a delegated staking model. Each validator is pre-staked with the sum of the amounts
+// delegated to it, in the order the validators first appear in the delegations.
+// The amounts delegated to the registered validators are written to the Delegations mapping,
+// so the build must support delegations (ex. a build registered with RegisterStakingSCBuild)
+func PredeployDelegatedStaking(
+	build StakingSCBuild,
+	delegations []Delegation,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	if build.Slots.Delegations == nil {
+		return nil, ErrDelegationsNotSupported
+	}
+
+	stakedValidators := make([]stakedValidator, 0)
+	validatorIndexes := make(map[types.Address]int)
+	delegated := make(map[types.Address]map[types.Address]*big.Int)
+
+	for _, delegation := range delegations {
+		if delegation.Amount == nil || delegation.Amount.Sign() <= 0 {
+			return nil, fmt.Errorf(
+				"%w, validator %s, delegator %s",
+				ErrInvalidDelegationAmount,
+				delegation.Validator,
+				delegation.Delegator,
+			)
+		}
+
+		indx, ok := validatorIndexes[delegation.Validator]
+		if !ok {
+			indx = len(stakedValidators)
+			validatorIndexes[delegation.Validator] = indx
+
+			stakedValidators = append(stakedValidators, stakedValidator{
+				address: delegation.Validator,
+				stake:   big.NewInt(0),
+			})
+			delegated[delegation.Validator] = make(map[types.Address]*big.Int)
+		}
+
+		// Update the validator total
+		stakedValidators[indx].stake.Add(stakedValidators[indx].stake, delegation.Amount)
+
+		// The same delegator can delegate to the same validator multiple times
+		amount, ok := delegated[delegation.Validator][delegation.Delegator]
+		if !ok {
+			amount = big.NewInt(0)
+			delegated[delegation.Validator][delegation.Delegator] = amount
+		}
+
+		amount.Add(amount, delegation.Amount)
+	}
+
+	stakingAccount, err := predeployStakingSC(build, stakedValidators, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// The delegations of the validators that are not registered (ex. when staking is disabled) are left out
+	for _, validator := range registeredValidators(stakedValidators, params) {
+		for delegator, amount := range delegated[validator.address] {
+			// Set the value for the validator -> delegator -> amount mapping
+			stakingAccount.Storage[types.BytesToHash(
+				getNestedAddressMapping(validator.address, delegator, *build.Slots.Delegations),
+			)] = types.BytesToHash(amount.Bytes())
+		}
+	}
+
+	return stakingAccount, nil
+}
+
+// AssertDelegationConsistency checks that the amounts delegated to each validator of the staking SC
+// account storage sum up to its staked amount, so a staked validator without delegations is inconsistent,
+// and that no amount is delegated to an address that is not a validator
+func AssertDelegationConsistency(account *chain.GenesisAccount, delegations []Delegation) error {
+	delegatedSums := make(map[types.Address]*big.Int)
+
+	for _, delegation := range delegations {
+		sum, ok := delegatedSums[delegation.Validator]
+		if !ok {
+			sum = big.NewInt(0)
+			delegatedSums[delegation.Validator] = sum
+		}
+
+		sum.Add(sum, delegation.Amount)
+	}
+
+	stakedValidators, err := decodeStakedValidators(account)
+	if err != nil {
+		return err
+	}
+
+	for _, validator := range stakedValidators {
+		delegatedSum, ok := delegatedSums[validator.address]
+		if !ok {
+			delegatedSum = big.NewInt(0)
+		}
+
+		if delegatedSum.Cmp(validator.stake) != 0 {
+			return fmt.Errorf(
+				"%w, validator %s, delegated %s, staked %s",
+				ErrDelegationMismatch,
+				validator.address,
+				delegatedSum,
+				validator.stake,
+			)
+		}
+
+		delete(delegatedSums, validator.address)
+	}
+
+	// The remaining delegations are to addresses that are not validators
+	for _, delegation := range delegations {
+		if delegatedSum, ok := delegatedSums[delegation.Validator]; ok && delegatedSum.Sign() != 0 {
+			return fmt.Errorf(
+				"%w, %s is not a validator, delegated %s",
+				ErrDelegationMismatch,
+				delegation.Validator,
+				delegatedSum,
+			)
+		}
+	}
+
+	return nil
+}
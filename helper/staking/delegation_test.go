@@ -0,0 +1,152 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// testDelegationKeys are the keys of the delegations in a mapping (address => mapping(address => uint256))
+// at slot 7, keccak(leftPad(delegator, 32) . keccak(leftPad(validator, 32) . leftPad(7, 32))), as computed by solc
+var testDelegationKeys = map[[2]types.Address]types.Hash{
+	{addr1, types.StringToAddress("11")}: types.StringToHash(
+		"0xdfb7339143670f2fc7676c1c841d8ba093f6afb02004aaab2ada654bf390aa2d",
+	),
+	{addr1, types.StringToAddress("22")}: types.StringToHash(
+		"0x11fe8107dfa5051b43f3b65ce3ce0c7239c3812e1edcee8a5b70e8bd9520df4d",
+	),
+	{addr2, types.StringToAddress("11")}: types.StringToHash(
+		"0xab852aafb5ac1838752ce84e98e400051fa6160e644b94681e67e9b53f38029a",
+	),
+}
+
+func TestGetNestedAddressMapping(t *testing.T) {
+	for pair, expected := range testDelegationKeys {
+		assert.Equal(t, expected, types.BytesToHash(getNestedAddressMapping(pair[0], pair[1], testMappingSlot)))
+	}
+}
+
+func TestPredeployDelegatedStaking(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	delegator1 := types.StringToAddress("11")
+	delegator2 := types.StringToAddress("22")
+
+	build := defaultStakingSCBuild
+	build.Slots.Delegations = &testMappingSlot
+
+	delegations := []Delegation{
+		{Validator: addr1, Delegator: delegator1, Amount: big.NewInt(100)},
+		{Validator: addr1, Delegator: delegator2, Amount: big.NewInt(200)},
+		{Validator: addr2, Delegator: delegator1, Amount: big.NewInt(50)},
+		{Validator: addr1, Delegator: delegator1, Amount: big.NewInt(10)},
+	}
+
+	t.Run("should write the delegations and aggregate the totals", func(t *testing.T) {
+		account, err := PredeployDelegatedStaking(build, delegations, params)
+		assert.NoError(t, err)
+
+		delegationSlot := func(validator, delegator types.Address) types.Hash {
+			return testDelegationKeys[[2]types.Address{validator, delegator}]
+		}
+
+		assert.Equal(t, types.BytesToHash(big.NewInt(110).Bytes()), account.Storage[delegationSlot(addr1, delegator1)])
+		assert.Equal(t, types.BytesToHash(big.NewInt(200).Bytes()), account.Storage[delegationSlot(addr1, delegator2)])
+		assert.Equal(t, types.BytesToHash(big.NewInt(50).Bytes()), account.Storage[delegationSlot(addr2, delegator1)])
+
+		stakedValidators, err := decodeStakedValidators(account)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []stakedValidator{
+			{address: addr1, stake: big.NewInt(310)},
+			{address: addr2, stake: big.NewInt(50)},
+		}, stakedValidators)
+
+		assert.Equal(t, big.NewInt(360), decodeTotalStakedAmount(account))
+		assert.Equal(t, big.NewInt(360), account.Balance)
+	})
+
+	t.Run("should not write the delegations when staking is disabled", func(t *testing.T) {
+		disabledParams := params
+		disabledParams.DisableStaking = true
+
+		account, err := PredeployDelegatedStaking(build, delegations, disabledParams)
+		assert.NoError(t, err)
+
+		for _, key := range testDelegationKeys {
+			assert.NotContains(t, account.Storage, key)
+		}
+	})
+
+	t.Run("should reject a delegations slot over a core slot", func(t *testing.T) {
+		collidingBuild := defaultStakingSCBuild
+		collidingSlot := minNumValidatorSlot
+		collidingBuild.Slots.Delegations = &collidingSlot
+
+		_, err := PredeployDelegatedStaking(collidingBuild, delegations, params)
+		assert.ErrorIs(t, err, ErrSlotCollision)
+	})
+
+	t.Run("should fail for the embedded staking SC", func(t *testing.T) {
+		_, err := PredeployDelegatedStaking(defaultStakingSCBuild, delegations, params)
+		assert.ErrorIs(t, err, ErrDelegationsNotSupported)
+	})
+
+	t.Run("should reject a non-positive amount", func(t *testing.T) {
+		_, err := PredeployDelegatedStaking(
+			build,
+			[]Delegation{{Validator: addr1, Delegator: delegator1, Amount: big.NewInt(0)}},
+			params,
+		)
+		assert.ErrorIs(t, err, ErrInvalidDelegationAmount)
+	})
+}
+
+func TestAssertDelegationConsistency(t *testing.T) {
+	delegator1 := types.StringToAddress("11")
+	delegator2 := types.StringToAddress("22")
+
+	delegations := []Delegation{
+		{Validator: addr1, Delegator: delegator1, Amount: big.NewInt(100)},
+		{Validator: addr1, Delegator: delegator2, Amount: big.NewInt(200)},
+		{Validator: addr2, Delegator: delegator1, Amount: big.NewInt(50)},
+	}
+
+	build := defaultStakingSCBuild
+	build.Slots.Delegations = &testMappingSlot
+
+	account, err := PredeployDelegatedStaking(
+		build,
+		delegations,
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	t.Run("should accept consistent delegations", func(t *testing.T) {
+		assert.NoError(t, AssertDelegationConsistency(account, delegations))
+	})
+
+	t.Run("should detect a missing delegation", func(t *testing.T) {
+		assert.ErrorIs(t, AssertDelegationConsistency(account, delegations[1:]), ErrDelegationMismatch)
+	})
+
+	t.Run("should detect a diverging validator total", func(t *testing.T) {
+		inconsistent := append([]Delegation{}, delegations...)
+		inconsistent[2] = Delegation{Validator: addr2, Delegator: delegator1, Amount: big.NewInt(51)}
+
+		assert.ErrorIs(t, AssertDelegationConsistency(account, inconsistent), ErrDelegationMismatch)
+	})
+
+	t.Run("should detect a staked validator without delegations", func(t *testing.T) {
+		assert.ErrorIs(t, AssertDelegationConsistency(account, delegations[:2]), ErrDelegationMismatch)
+	})
+
+	t.Run("should detect a delegation to an address that is not a validator", func(t *testing.T) {
+		extra := append([]Delegation{}, delegations...)
+		extra = append(extra, Delegation{Validator: types.StringToAddress("3"), Delegator: delegator1, Amount: big.NewInt(1)})
+
+		assert.ErrorIs(t, AssertDelegationConsistency(account, extra), ErrDelegationMismatch)
+	})
+}
@@ -0,0 +1,76 @@
+package staking
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// GenesisContentHash returns a digest of the genesis account content (code, nonce, balance and storage),
+// which is equal for two accounts only if their content is equal.
+// It allows nodes to quickly compare the predeployed staking SC accounts they generated
+func GenesisContentHash(account *chain.GenesisAccount) types.Hash {
+	hash := keccak.NewKeccak256()
+
+	// The code has a variable length, so it's prefixed with its length
+	var lengthBuf [8]byte
+
+	binary.BigEndian.PutUint64(lengthBuf[:], uint64(len(account.Code)))
+	_, _ = hash.Write(lengthBuf[:])
+	_, _ = hash.Write(account.Code)
+
+	var nonceBuf [8]byte
+
+	binary.BigEndian.PutUint64(nonceBuf[:], account.Nonce)
+	_, _ = hash.Write(nonceBuf[:])
+
+	_, _ = hash.Write(types.BytesToHash(accountBalance(account).Bytes()).Bytes())
+
+	// Sort the storage keys, so the digest doesn't depend on the map iteration order
+	for _, key := range sortedStorageKeys(account.Storage) {
+		value := account.Storage[key]
+
+		_, _ = hash.Write(key.Bytes())
+		_, _ = hash.Write(value.Bytes())
+	}
+
+	return types.BytesToHash(hash.Sum(nil))
+}
+
+// sortedStorageKeys returns the keys of the storage in ascending order
+func sortedStorageKeys(storage map[types.Hash]types.Hash) []types.Hash {
+	keys := make([]types.Hash, 0, len(storage))
+	for key := range storage {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) < 0
+	})
+
+	return keys
+}
+
+// ValidatorSetID returns an identifier of the validator set, which nodes can compare to confirm
+// they share the same genesis validator set before connecting.
+// It's the keccak hash of the concatenated addresses, sorted in ascending order,
+// so it doesn't depend on the order of the validators
+func ValidatorSetID(validators []types.Address) types.Hash {
+	sorted := make([]types.Address, len(validators))
+	copy(sorted, validators)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Bytes(), sorted[j].Bytes()) < 0
+	})
+
+	hash := keccak.NewKeccak256()
+	for _, validator := range sorted {
+		_, _ = hash.Write(validator.Bytes())
+	}
+
+	return types.BytesToHash(hash.Sum(nil))
+}
@@ -0,0 +1,83 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenesisContentHash(t *testing.T) {
+	newAccount := func(t *testing.T) *chain.GenesisAccount {
+		t.Helper()
+
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		return account
+	}
+
+	expected := GenesisContentHash(newAccount(t))
+
+	t.Run("should be equal for identical accounts", func(t *testing.T) {
+		assert.Equal(t, expected, GenesisContentHash(newAccount(t)))
+	})
+
+	tests := []struct {
+		name   string
+		modify func(account *chain.GenesisAccount)
+	}{
+		{
+			"code byte",
+			func(account *chain.GenesisAccount) {
+				account.Code[0] ^= 0x01
+			},
+		},
+		{
+			"balance",
+			func(account *chain.GenesisAccount) {
+				account.Balance = new(big.Int).Add(account.Balance, big.NewInt(1))
+			},
+		},
+		{
+			"nonce",
+			func(account *chain.GenesisAccount) {
+				account.Nonce = 1
+			},
+		},
+		{
+			"storage value",
+			func(account *chain.GenesisAccount) {
+				account.Storage[SlotKey(minNumValidatorSlot)] = types.BytesToHash(big.NewInt(2).Bytes())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("should change with the "+tt.name, func(t *testing.T) {
+			account := newAccount(t)
+			tt.modify(account)
+
+			assert.NotEqual(t, expected, GenesisContentHash(account))
+		})
+	}
+}
+
+func TestValidatorSetID(t *testing.T) {
+	addr3 := types.StringToAddress("3")
+
+	validators := []types.Address{addr1, addr2, addr3}
+
+	id := ValidatorSetID(validators)
+
+	assert.Equal(t, id, ValidatorSetID([]types.Address{addr3, addr1, addr2}))
+	assert.NotEqual(t, id, ValidatorSetID([]types.Address{addr1, addr2}))
+
+	// The input slice must not be reordered
+	assert.Equal(t, []types.Address{addr1, addr2, addr3}, validators)
+}
@@ -0,0 +1,43 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrInvalidDistributedStake = errors.New("distributed stake must be a positive amount")
+)
+
+// StakeDistribution returns the stake of the validator at index i
+type StakeDistribution func(i int) *big.Int
+
+// PredeployStakingSCWithDistribution is a helper method for setting up the staking smart contract account,
+// using the passed in validators as pre-staked validators, each staked with the amount returned by dist.
+// It allows simulated networks to spread the stake (ex. geometric or seeded random distributions)
+func PredeployStakingSCWithDistribution(
+	validators []types.Address,
+	dist StakeDistribution,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	stakedValidators := make([]stakedValidator, len(validators))
+
+	for indx, validator := range validators {
+		stake := dist(indx)
+		if stake == nil || stake.Sign() <= 0 {
+			return nil, fmt.Errorf("%w, validator %s", ErrInvalidDistributedStake, validator)
+		}
+
+		stakedValidators[indx] = stakedValidator{
+			address: validator,
+			// Copy the stake, so the distribution can reuse its values
+			stake: new(big.Int).Set(stake),
+		}
+	}
+
+	return predeployStakingSC(defaultStakingSCBuild, stakedValidators, params)
+}
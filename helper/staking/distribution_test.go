@@ -0,0 +1,45 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployStakingSCWithDistribution(t *testing.T) {
+	validators := []types.Address{addr1, addr2, types.StringToAddress("3")}
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	t.Run("should stake the validators with a linear distribution", func(t *testing.T) {
+		linear := func(i int) *big.Int {
+			return big.NewInt(int64(i+1) * 100)
+		}
+
+		account, err := PredeployStakingSCWithDistribution(validators, linear, params)
+		assert.NoError(t, err)
+
+		for indx, validator := range validators {
+			assert.Equal(
+				t,
+				types.BytesToHash(linear(indx).Bytes()),
+				account.Storage[types.BytesToHash(getStorageIndexes(validator, int64(indx)).AddressToStakedAmountIndex)],
+			)
+		}
+
+		assert.Equal(t, big.NewInt(600), decodeTotalStakedAmount(account))
+		assert.Equal(t, big.NewInt(600), account.Balance)
+	})
+
+	t.Run("should reject a non-positive stake", func(t *testing.T) {
+		_, err := PredeployStakingSCWithDistribution(
+			validators,
+			func(i int) *big.Int {
+				return big.NewInt(int64(i))
+			},
+			params,
+		)
+		assert.ErrorIs(t, err, ErrInvalidDistributedStake)
+	})
+}
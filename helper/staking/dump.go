@@ -0,0 +1,62 @@
+package staking
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+)
+
+var (
+	ErrStakeSumMismatch = errors.New("sum of the validator stakes doesn't match the total staked amount")
+)
+
+// PredeployFromStateDump generates a fresh staking SC genesis account with the same validators and stakes
+// as the staking SC account in the JSON state dump at dumpPath.
+// The dump is the genesis account encoding of the source chain staking SC account,
+// and its invariants are validated before reusing it
+func PredeployFromStateDump(dumpPath string, params PredeployParams) (*chain.GenesisAccount, error) {
+	data, err := ioutil.ReadFile(dumpPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read state dump, %w", err)
+	}
+
+	dump := &chain.GenesisAccount{}
+	if err := json.Unmarshal(data, dump); err != nil {
+		return nil, fmt.Errorf("unable to parse state dump, %w", err)
+	}
+
+	stakedValidators, err := decodeSourceValidators(dump)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state dump, %w", err)
+	}
+
+	return predeployStakingSC(defaultStakingSCBuild, stakedValidators, params)
+}
+
+// decodeSourceValidators decodes the validators and their stakes from the storage of
+// a source chain staking SC account, after checking the storage invariants
+func decodeSourceValidators(source *chain.GenesisAccount) ([]stakedValidator, error) {
+	if err := CheckUniqueValidatorIndexes(source); err != nil {
+		return nil, err
+	}
+
+	stakedValidators, err := decodeStakedValidators(source)
+	if err != nil {
+		return nil, err
+	}
+
+	stakeSum := big.NewInt(0)
+	for _, validator := range stakedValidators {
+		stakeSum.Add(stakeSum, validator.stake)
+	}
+
+	if totalStaked := decodeTotalStakedAmount(source); stakeSum.Cmp(totalStaked) != 0 {
+		return nil, fmt.Errorf("%w, stakes sum %s, total staked %s", ErrStakeSumMismatch, stakeSum, totalStaked)
+	}
+
+	return stakedValidators, nil
+}
@@ -0,0 +1,89 @@
+package staking
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeStateDump(t *testing.T, account *chain.GenesisAccount) string {
+	t.Helper()
+
+	data, err := json.Marshal(account)
+	assert.NoError(t, err)
+
+	dumpPath := filepath.Join(t.TempDir(), "dump.json")
+	assert.NoError(t, ioutil.WriteFile(dumpPath, data, 0600))
+
+	return dumpPath
+}
+
+func TestPredeployFromStateDump(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	sourceAccount := func(t *testing.T) *chain.GenesisAccount {
+		t.Helper()
+
+		account, err := predeployStakingSC(
+			defaultStakingSCBuild,
+			[]stakedValidator{
+				{address: addr1, stake: big.NewInt(100)},
+				{address: addr2, stake: big.NewInt(200)},
+			},
+			params,
+		)
+		assert.NoError(t, err)
+
+		return account
+	}
+
+	t.Run("should predeploy the validators of the dump", func(t *testing.T) {
+		account, err := PredeployFromStateDump(writeStateDump(t, sourceAccount(t)), params)
+		assert.NoError(t, err)
+
+		validators, err := DecodeValidators(account)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []types.Address{addr1, addr2}, validators)
+		assert.Equal(t, big.NewInt(300), decodeTotalStakedAmount(account))
+		assert.Equal(t, big.NewInt(300), account.Balance)
+	})
+
+	t.Run("should reject a dump with a wrong total staked amount", func(t *testing.T) {
+		dump := sourceAccount(t)
+		dump.Storage[SlotKey(stakedAmountSlot)] = types.BytesToHash(big.NewInt(299).Bytes())
+
+		_, err := PredeployFromStateDump(writeStateDump(t, dump), params)
+		assert.ErrorIs(t, err, ErrStakeSumMismatch)
+	})
+
+	t.Run("should reject a dump with a corrupted validator index", func(t *testing.T) {
+		dump := sourceAccount(t)
+		dump.Storage[types.BytesToHash(getStorageIndexes(addr2, 1).AddressToValidatorIndexIndex)] = types.ZeroHash
+
+		_, err := PredeployFromStateDump(writeStateDump(t, dump), params)
+		assert.ErrorIs(t, err, ErrDuplicateValidatorIndex)
+	})
+
+	t.Run("should reject a dump with an oversized validators array", func(t *testing.T) {
+		// A size of 2^52 would panic if the array was allocated before validating it
+		dump := sourceAccount(t)
+		dump.Storage[SlotKey(validatorsSlot)] = types.BytesToHash(big.NewInt(1 << 52).Bytes())
+
+		assert.NotPanics(t, func() {
+			_, err := PredeployFromStateDump(writeStateDump(t, dump), params)
+			assert.ErrorIs(t, err, ErrInvalidValidatorsArraySize)
+		})
+	})
+
+	t.Run("should fail for a missing dump", func(t *testing.T) {
+		_, err := PredeployFromStateDump(filepath.Join(t.TempDir(), "missing.json"), params)
+		assert.Error(t, err)
+	})
+}
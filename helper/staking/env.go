@@ -0,0 +1,43 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrInvalidEnvValidator = errors.New("invalid validator address in the environment")
+
+// LoadValidatorsFromEnv reads the validator addresses from the PREFIX_VALIDATOR_0, PREFIX_VALIDATOR_1, ...
+// environment variables, stopping at the first missing index, so containerized deployments can
+// configure the validator set through the environment. Each value must be a non-zero hex address
+func LoadValidatorsFromEnv(prefix string) ([]types.Address, error) {
+	validators := make([]types.Address, 0)
+
+	for indx := 0; ; indx++ {
+		name := fmt.Sprintf("%s_VALIDATOR_%d", prefix, indx)
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			break
+		}
+
+		buf, err := hex.DecodeHex(strings.TrimSpace(value))
+		if err != nil || len(buf) != types.AddressLength {
+			return nil, fmt.Errorf("%w, %s=%q", ErrInvalidEnvValidator, name, value)
+		}
+
+		validator := types.BytesToAddress(buf)
+		if validator == types.ZeroAddress {
+			return nil, fmt.Errorf("%w, %s is the zero address", ErrInvalidEnvValidator, name)
+		}
+
+		validators = append(validators, validator)
+	}
+
+	return validators, nil
+}
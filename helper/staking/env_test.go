@@ -0,0 +1,46 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadValidatorsFromEnv(t *testing.T) {
+	t.Run("should load the contiguous validators", func(t *testing.T) {
+		t.Setenv("CHAIN_VALIDATOR_0", addr1.String())
+		t.Setenv("CHAIN_VALIDATOR_1", addr2.String())
+
+		validators, err := LoadValidatorsFromEnv("CHAIN")
+		assert.NoError(t, err)
+
+		assert.Equal(t, []types.Address{addr1, addr2}, validators)
+	})
+
+	t.Run("should stop at the first gap", func(t *testing.T) {
+		t.Setenv("CHAIN_VALIDATOR_0", addr1.String())
+		t.Setenv("CHAIN_VALIDATOR_2", addr2.String())
+
+		validators, err := LoadValidatorsFromEnv("CHAIN")
+		assert.NoError(t, err)
+
+		assert.Equal(t, []types.Address{addr1}, validators)
+	})
+
+	t.Run("should return no validators without variables", func(t *testing.T) {
+		validators, err := LoadValidatorsFromEnv("CHAIN")
+		assert.NoError(t, err)
+
+		assert.Empty(t, validators)
+	})
+
+	t.Run("should reject invalid addresses", func(t *testing.T) {
+		for _, value := range []string{"0x1234", "not an address", types.ZeroAddress.String()} {
+			t.Setenv("CHAIN_VALIDATOR_0", value)
+
+			_, err := LoadValidatorsFromEnv("CHAIN")
+			assert.ErrorIs(t, err, ErrInvalidEnvValidator)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const etherDecimals = 18
+
+var (
+	ErrInvalidEtherStake = errors.New("invalid ether stake")
+
+	// weiPerEther is 10^18
+	weiPerEther = new(big.Int).Exp(big.NewInt(10), big.NewInt(etherDecimals), nil)
+)
+
+// ParseEtherStake parses a stake expressed in decimal ether (ex. "10", "10.5" or "10 ether"),
+// with an optional "ether" or "eth" suffix, into wei.
+// The parsing uses integer math only, so no precision is lost
+func ParseEtherStake(s string) (*big.Int, error) {
+	value := strings.TrimSpace(s)
+
+	// Strip the optional unit suffix
+	for _, suffix := range []string{"ether", "eth"} {
+		if strings.HasSuffix(strings.ToLower(value), suffix) {
+			value = strings.TrimSpace(value[:len(value)-len(suffix)])
+
+			break
+		}
+	}
+
+	whole, fraction := value, ""
+	if dot := strings.IndexByte(value, '.'); dot != -1 {
+		whole, fraction = value[:dot], value[dot+1:]
+	}
+
+	if (whole == "" && fraction == "") || !isDecimal(whole) || !isDecimal(fraction) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidEtherStake, s)
+	}
+
+	if len(fraction) > etherDecimals {
+		return nil, fmt.Errorf("%w: %q has more than %d decimals", ErrInvalidEtherStake, s, etherDecimals)
+	}
+
+	// The stake in wei is the whole and fraction digits, scaled by the missing decimals
+	digits := whole + fraction + strings.Repeat("0", etherDecimals-len(fraction))
+
+	wei, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidEtherStake, s)
+	}
+
+	return wei, nil
+}
+
+// isDecimal checks if the string contains only decimal digits
+func isDecimal(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
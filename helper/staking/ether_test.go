@@ -0,0 +1,50 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEtherStake(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string // wei
+	}{
+		{"10", "10000000000000000000"},
+		{"0", "0"},
+		{"10.5", "10500000000000000000"},
+		{".5", "500000000000000000"},
+		{"0.000000000000000001", "1"},
+		{"10 ether", "10000000000000000000"},
+		{"10ETH", "10000000000000000000"},
+		{" 1.25 eth ", "1250000000000000000"},
+		{"123456789012345678901234567890", "123456789012345678901234567890000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			stake, err := ParseEtherStake(tt.input)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expected, stake.String())
+		})
+	}
+
+	for _, input := range []string{
+		"",
+		"ether",
+		".",
+		"-1",
+		"1e18",
+		"10.5.5",
+		"0x10",
+		"1 wei",
+		"0.0000000000000000001",
+	} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, err := ParseEtherStake(input)
+			assert.ErrorIs(t, err, ErrInvalidEtherStake)
+		})
+	}
+}
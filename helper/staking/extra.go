@@ -0,0 +1,83 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var ErrInvalidIBFTExtra = errors.New("invalid IBFT extra data")
+
+// ibftExtraVanity is the number of extra data bytes reserved for the proposer vanity.
+// It mirrors the IBFT consensus constant, which can't be imported since the consensus imports this package
+const ibftExtraVanity = 32
+
+// ValidatorsToIBFTExtra returns the genesis block extra data for IBFT with the validators of the staking SC account,
+// so the consensus validator set matches the staking SC validator set. The extra data is the zero vanity,
+// followed by the RLP encoded IBFT extra with the validators and empty seal placeholders
+func ValidatorsToIBFTExtra(account *chain.GenesisAccount) ([]byte, error) {
+	validators, err := DecodeValidators(account)
+	if err != nil {
+		return nil, err
+	}
+
+	extra := make([]byte, ibftExtraVanity)
+
+	return types.MarshalRLPTo(func(ar *fastrlp.Arena) *fastrlp.Value {
+		vv := ar.NewArray()
+
+		// Validators
+		vals := ar.NewArray()
+		for _, validator := range validators {
+			vals.Set(ar.NewBytes(validator.Bytes()))
+		}
+
+		vv.Set(vals)
+
+		// Seal
+		vv.Set(ar.NewNull())
+
+		// CommittedSeal
+		vv.Set(ar.NewNullArray())
+
+		return vv
+	}, extra), nil
+}
+
+// ValidatorsFromIBFTExtra returns the validators of the genesis block extra data for IBFT,
+// the inverse of ValidatorsToIBFTExtra
+func ValidatorsFromIBFTExtra(extraData []byte) ([]types.Address, error) {
+	if len(extraData) < ibftExtraVanity {
+		return nil, fmt.Errorf("%w, %d bytes is shorter than the vanity", ErrInvalidIBFTExtra, len(extraData))
+	}
+
+	parser := &fastrlp.Parser{}
+
+	value, err := parser.Parse(extraData[ibftExtraVanity:])
+	if err != nil {
+		return nil, fmt.Errorf("%w, %v", ErrInvalidIBFTExtra, err)
+	}
+
+	elems, err := value.GetElems()
+	if err != nil || len(elems) == 0 {
+		return nil, fmt.Errorf("%w, extra is not a list", ErrInvalidIBFTExtra)
+	}
+
+	validatorElems, err := elems[0].GetElems()
+	if err != nil {
+		return nil, fmt.Errorf("%w, validators are not a list", ErrInvalidIBFTExtra)
+	}
+
+	validators := make([]types.Address, len(validatorElems))
+
+	for indx, elem := range validatorElems {
+		if err := elem.GetAddr(validators[indx][:]); err != nil {
+			return nil, fmt.Errorf("%w, validator %d, %v", ErrInvalidIBFTExtra, indx, err)
+		}
+	}
+
+	return validators, nil
+}
@@ -0,0 +1,53 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/fastrlp"
+)
+
+func TestValidatorsToIBFTExtra(t *testing.T) {
+	account, err := PredeployStakingSC(
+		[]types.Address{addr1, addr2},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	extra, err := ValidatorsToIBFTExtra(account)
+	assert.NoError(t, err)
+
+	// The vanity is empty
+	assert.Equal(t, make([]byte, ibftExtraVanity), extra[:ibftExtraVanity])
+
+	parser := &fastrlp.Parser{}
+
+	value, err := parser.Parse(extra[ibftExtraVanity:])
+	assert.NoError(t, err)
+
+	elems, err := value.GetElems()
+	assert.NoError(t, err)
+	assert.Len(t, elems, 3)
+
+	// Validators
+	validatorElems, err := elems[0].GetElems()
+	assert.NoError(t, err)
+
+	validators := make([]types.Address, len(validatorElems))
+	for indx, elem := range validatorElems {
+		assert.NoError(t, elem.GetAddr(validators[indx][:]))
+	}
+
+	assert.Equal(t, []types.Address{addr1, addr2}, validators)
+
+	// Seal
+	seal, err := elems[1].Bytes()
+	assert.NoError(t, err)
+	assert.Empty(t, seal)
+
+	// CommittedSeal
+	committedSeal, err := elems[2].GetElems()
+	assert.NoError(t, err)
+	assert.Empty(t, committedSeal)
+}
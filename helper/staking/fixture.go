@@ -0,0 +1,103 @@
+package staking
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// WriteGoldenFixture predeploys the staking SC with the passed in validators, and writes
+// a deterministic text representation of the account to w, for golden file tests.
+// The storage is written one entry per line in ascending key order, so changes show up as line diffs
+func WriteGoldenFixture(w io.Writer, validators []types.Address, params PredeployParams) error {
+	stakingAccount, err := PredeployStakingSC(validators, params)
+	if err != nil {
+		return err
+	}
+
+	minCount, maxCount, err := GetValidatorBounds(stakingAccount.Storage)
+	if err != nil {
+		return err
+	}
+
+	// The keys are labeled with the validators the predeploy wrote, in their array order,
+	// which differs from the passed in validators when they are sorted or not registered
+	registered, err := DecodeValidators(stakingAccount)
+	if err != nil {
+		return err
+	}
+
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintf(buf, "codeHash: %s\n", types.BytesToHash(keccak.Keccak256(nil, stakingAccount.Code)))
+	fmt.Fprintf(buf, "balance: %s\n", accountBalance(stakingAccount))
+	fmt.Fprintf(buf, "minValidatorCount: %d\n", minCount)
+	fmt.Fprintf(buf, "maxValidatorCount: %d\n", maxCount)
+	fmt.Fprintf(buf, "storage:\n")
+
+	for _, key := range sortedStorageKeys(stakingAccount.Storage) {
+		fmt.Fprintf(
+			buf,
+			"  %s: %s # %s\n",
+			key,
+			stakingAccount.Storage[key],
+			LabelStorageKey(key, registered),
+		)
+	}
+
+	return buf.Flush()
+}
+
+// maxRandomStakeETH is the upper bound of the stake over the staking threshold of the random validators, in ETH
+const maxRandomStakeETH = 100
+
+// RandomStakingAccount returns a staking SC account with n validators, with pseudo-random addresses
+// and stakes (between the staking threshold and the threshold plus 100 ETH) generated from seed.
+// The same seed always produces the same account, so it gives reproducible inputs for
+// state and trie property-based tests. It panics if n is negative
+func RandomStakingAccount(seed int64, n int) *chain.GenesisAccount {
+	// The account must be reproducible, so the source is seeded instead of cryptographically random
+	//nolint:gosec
+	random := rand.New(rand.NewSource(seed))
+
+	stakedValidators := make([]stakedValidator, n)
+	seen := make(map[types.Address]struct{}, n)
+
+	for indx := range stakedValidators {
+		var validator types.Address
+
+		// Skip the zero address and the duplicates, so the set stays valid for any seed
+		for {
+			_, _ = random.Read(validator[:])
+
+			if _, ok := seen[validator]; !ok && validator != types.ZeroAddress {
+				break
+			}
+		}
+
+		seen[validator] = struct{}{}
+
+		stake := new(big.Int).Mul(big.NewInt(random.Int63n(maxRandomStakeETH*1e9)), big.NewInt(1e9))
+
+		stakedValidators[indx] = stakedValidator{
+			address: validator,
+			stake:   stake.Add(stake, EmbeddedStakingThreshold()),
+		}
+	}
+
+	stakingAccount, err := predeployStakingSC(defaultStakingSCBuild, stakedValidators, PredeployParams{
+		MinValidatorCount: MinValidatorCount,
+		MaxValidatorCount: MaxValidatorCount,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("unable to predeploy the random staking SC, %v", err))
+	}
+
+	return stakingAccount
+}
@@ -0,0 +1,96 @@
+package staking
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteGoldenFixture(t *testing.T) {
+	validators := []types.Address{addr1, addr2}
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	var expected bytes.Buffer
+
+	assert.NoError(t, WriteGoldenFixture(&expected, validators, params))
+
+	// The fixture is byte-stable across runs
+	for i := 0; i < 10; i++ {
+		var fixture bytes.Buffer
+
+		assert.NoError(t, WriteGoldenFixture(&fixture, validators, params))
+		assert.Equal(t, expected.String(), fixture.String())
+	}
+
+	lines := strings.Split(strings.TrimSuffix(expected.String(), "\n"), "\n")
+
+	assert.True(t, strings.HasPrefix(lines[0], "codeHash: 0x"))
+	assert.Equal(t, "balance: 20000000000000000000", lines[1])
+	assert.Equal(t, "minValidatorCount: 1", lines[2])
+	assert.Equal(t, "maxValidatorCount: 10", lines[3])
+	assert.Equal(t, "storage:", lines[4])
+
+	account, err := PredeployStakingSC(validators, params)
+	assert.NoError(t, err)
+
+	assert.Len(t, lines[5:], len(account.Storage))
+	assert.Contains(t, lines, "  "+SlotKey(minNumValidatorSlot).String()+": "+
+		types.BytesToHash([]byte{1}).String()+" # minNumValidators")
+}
+
+func TestRandomStakingAccount(t *testing.T) {
+	account := RandomStakingAccount(42, 5)
+
+	// The same seed yields the same account
+	assert.Equal(t, account, RandomStakingAccount(42, 5))
+	assert.NotEqual(t, account, RandomStakingAccount(43, 5))
+
+	validators, err := DecodeValidators(account)
+	assert.NoError(t, err)
+	assert.Len(t, validators, 5)
+
+	assert.NoError(t, AssertBalanceEqualsStake(account))
+	assert.NoError(t, AssertStakesMeetThreshold(account, nil))
+	assert.NoError(t, CheckUniqueValidatorIndexes(account))
+}
+
+func TestWriteGoldenFixtureSortedValidators(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10, SortValidators: true}
+
+	var fixture bytes.Buffer
+
+	assert.NoError(t, WriteGoldenFixture(&fixture, []types.Address{addr2, addr1}, params))
+
+	lines := strings.Split(fixture.String(), "\n")
+
+	// addr1 is written first, so the labels follow the sorted array order
+	for indx, validator := range []types.Address{addr1, addr2} {
+		storageIndexes := getStorageIndexes(validator, int64(indx))
+
+		assert.Contains(
+			t,
+			lines,
+			fmt.Sprintf(
+				"  %s: %s # validators[%d]",
+				types.BytesToHash(storageIndexes.ValidatorsIndex),
+				types.BytesToHash(validator.Bytes()),
+				indx,
+			),
+		)
+		assert.Contains(
+			t,
+			lines,
+			fmt.Sprintf(
+				"  %s: %s # validatorIndex[%s]",
+				types.BytesToHash(storageIndexes.AddressToValidatorIndexIndex),
+				types.BytesToHash(big.NewInt(int64(indx)).Bytes()),
+				validator,
+			),
+		)
+	}
+}
@@ -0,0 +1,79 @@
+package staking
+
+import (
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+)
+
+// Fork names, as used in the chain params forks config
+const (
+	forkHomestead      = "homestead"
+	forkByzantium      = "byzantium"
+	forkConstantinople = "constantinople"
+	forkIstanbul       = "istanbul"
+	forkLondon         = "london"
+	forkShanghai       = "shanghai"
+)
+
+// forkOrder is the activation order of the forks that enable opcodes
+var forkOrder = []string{
+	forkHomestead,
+	forkByzantium,
+	forkConstantinople,
+	forkIstanbul,
+	forkLondon,
+	forkShanghai,
+}
+
+// opcodeForks maps the opcodes introduced by a fork to that fork.
+// The EVM doesn't implement BASEFEE and PUSH0, and the chain params have no London
+// or Shanghai fork, so code that requires them can't run on the chain
+var opcodeForks = map[byte]string{
+	evm.DELEGATECALL:   forkHomestead,
+	evm.RETURNDATASIZE: forkByzantium,
+	evm.RETURNDATACOPY: forkByzantium,
+	evm.STATICCALL:     forkByzantium,
+	evm.REVERT:         forkByzantium,
+	evm.SHL:            forkConstantinople,
+	evm.SHR:            forkConstantinople,
+	evm.SAR:            forkConstantinople,
+	evm.EXTCODEHASH:    forkConstantinople,
+	evm.CREATE2:        forkConstantinople,
+	evm.CHAINID:        forkIstanbul,
+	evm.SELFBALANCE:    forkIstanbul,
+	0x48:               forkLondon,   // BASEFEE
+	0x5F:               forkShanghai, // PUSH0
+}
+
+// RelevantForks returns the forks that must be enabled for the code to run,
+// in their activation order. It scans the code for opcodes introduced by the forks,
+// skipping the push data and stopping at the compiler metadata.
+// Data embedded in the code is scanned as opcodes as well, so the result can include
+// forks the code doesn't need
+func RelevantForks(code []byte) []string {
+	code = codeWithoutMetadata(code)
+
+	required := make(map[string]bool)
+
+	for pc := 0; pc < len(code); pc++ {
+		op := code[pc]
+
+		if fork, ok := opcodeForks[op]; ok {
+			required[fork] = true
+		}
+
+		// Skip the push data
+		if op >= evm.PUSH1 && op <= evm.PUSH32 {
+			pc += int(op - evm.PUSH1 + 1)
+		}
+	}
+
+	forks := make([]string, 0, len(required))
+
+	for _, fork := range forkOrder {
+		if required[fork] {
+			forks = append(forks, fork)
+		}
+	}
+
+	return forks
+}
@@ -0,0 +1,80 @@
+package staking
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelevantForks(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected []string
+	}{
+		{
+			"no fork specific opcodes",
+			// PUSH1 0x80 PUSH1 0x40 MSTORE
+			"0x6080604052",
+			[]string{},
+		},
+		{
+			"forks in activation order",
+			// CHAINID SHL REVERT
+			"0x461bfd",
+			[]string{forkByzantium, forkConstantinople, forkIstanbul},
+		},
+		{
+			"opcode in push data",
+			// PUSH2 CHAINID SELFBALANCE
+			"0x614647",
+			[]string{},
+		},
+		{
+			"BASEFEE opcode",
+			// BASEFEE PUSH1 0x00 MSTORE
+			"0x48600052",
+			[]string{forkLondon},
+		},
+		{
+			"PUSH0 opcode",
+			// PUSH0 PUSH0 MSTORE
+			"0x5f5f52",
+			[]string{forkShanghai},
+		},
+		{
+			"BASEFEE and PUSH0 in push data",
+			// PUSH2 BASEFEE PUSH0
+			"0x61485f",
+			[]string{},
+		},
+		{
+			"BASEFEE and PUSH0 in metadata",
+			// PUSH1 0x01, followed by the metadata {"solc": 0x00485f}
+			"0x6001a164736f6c634300485f000a",
+			[]string{},
+		},
+		{
+			"BASEFEE and PUSH0 in constructor args",
+			// PUSH1 0x01, followed by the metadata {"solc": 0x00485f} and a 0x485f argument
+			"0x6001a164736f6c634300485f000a" + strings.Repeat("00", 30) + "485f",
+			[]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RelevantForks(hex.MustDecodeHex(tt.code)))
+		})
+	}
+
+	t.Run("should leave out the metadata of the embedded staking SC", func(t *testing.T) {
+		assert.Equal(
+			t,
+			[]string{forkByzantium, forkConstantinople},
+			RelevantForks(hex.MustDecodeHex(StakingSCBytecode)),
+		)
+	})
+}
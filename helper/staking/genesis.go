@@ -0,0 +1,95 @@
+package staking
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	testGenesisChainID  = 100
+	testGenesisGasLimit = 5242880 // 0x500000
+	testGenesisGasUsed  = 458752  // 0x70000
+
+	// testGenesisEpochSize is the IBFT epoch size of the test genesis
+	testGenesisEpochSize = ibftDefaultEpochSize
+)
+
+// NewTestGenesis returns a minimal genesis for integration tests, with all forks enabled
+// and the staking SC predeployed using the passed in validators and the default params.
+// The IBFT PoS engine config and the genesis extra data are set the same way the genesis command sets them,
+// so a chain can be booted from the genesis
+func NewTestGenesis(validators []types.Address) (*chain.Genesis, error) {
+	stakingAccount, err := PredeployStakingSC(validators, PredeployParams{
+		MinValidatorCount: MinValidatorCount,
+		MaxValidatorCount: MaxValidatorCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	extraData, err := ValidatorsToIBFTExtra(stakingAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chain.Genesis{
+		Config: &chain.Params{
+			ChainID: testGenesisChainID,
+			Forks:   chain.AllForksEnabled,
+			Engine: map[string]interface{}{
+				ibftEngine: map[string]interface{}{
+					"type":      ibftPoS,
+					"epochSize": uint64(testGenesisEpochSize),
+				},
+			},
+		},
+		ExtraData:  extraData,
+		GasLimit:   testGenesisGasLimit,
+		GasUsed:    testGenesisGasUsed,
+		Difficulty: 1,
+		Alloc: map[types.Address]*chain.GenesisAccount{
+			stakingContracts.AddrStakingContract: stakingAccount,
+		},
+	}, nil
+}
+
+// DevGenesisAccount returns the staking SC account for a local single validator dev chain,
+// with the validator pre-staked with the default staked balance and the default validator bounds.
+// It panics only if the embedded staking SC defaults are invalid
+func DevGenesisAccount(validator types.Address) *chain.GenesisAccount {
+	stakingAccount, err := PredeployStakingSC([]types.Address{validator}, PredeployParams{
+		MinValidatorCount: MinValidatorCount,
+		MaxValidatorCount: MaxValidatorCount,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("unable to predeploy the dev staking SC, %v", err))
+	}
+
+	return stakingAccount
+}
+
+// GenesisPatch returns a JSON merge patch (RFC 7386) that adds the staking SC account, predeployed
+// with the passed in validators, to the alloc of a genesis.json file. It lets operators add the staking SC
+// to a hand maintained genesis file. Since the patch merges objects, a staking SC account that is already
+// in the genesis file keeps its storage slots that are not in the patch, so the address should be free
+// (see CheckStakingAddressFree)
+func GenesisPatch(validators []types.Address, params PredeployParams) ([]byte, error) {
+	stakingAccount, err := PredeployStakingSC(validators, params)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := map[string]interface{}{
+		"genesis": map[string]interface{}{
+			"alloc": map[string]*chain.GenesisAccount{
+				stakingContracts.AddrStakingContract.String(): stakingAccount,
+			},
+		},
+	}
+
+	return json.Marshal(patch)
+}
@@ -0,0 +1,136 @@
+package staking
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTestGenesis(t *testing.T) {
+	validators := []types.Address{addr1, addr2}
+
+	genesis, err := NewTestGenesis(validators)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(genesis)
+	assert.NoError(t, err)
+
+	decoded := &chain.Genesis{}
+	assert.NoError(t, json.Unmarshal(data, decoded))
+
+	stakingAccount, ok := decoded.Alloc[stakingContracts.AddrStakingContract]
+	assert.True(t, ok)
+	assert.Equal(t, genesis.Alloc[stakingContracts.AddrStakingContract], stakingAccount)
+	assert.Equal(t, genesis.GasLimit, decoded.GasLimit)
+
+	decodedValidators, err := DecodeValidators(stakingAccount)
+	assert.NoError(t, err)
+	assert.Equal(t, validators, decodedValidators)
+
+	// The IBFT engine config and extra data match the predeployed validators.
+	// The params are in the chain file next to the genesis, so they are encoded separately
+	paramsData, err := json.Marshal(genesis.Config)
+	assert.NoError(t, err)
+
+	decodedParams := &chain.Params{}
+	assert.NoError(t, json.Unmarshal(paramsData, decodedParams))
+
+	assert.Equal(t, map[string]interface{}{
+		"ibft": map[string]interface{}{
+			"type":      "PoS",
+			"epochSize": float64(testGenesisEpochSize),
+		},
+	}, decodedParams.Engine)
+	assert.NoError(t, AssertMatchesChainParams(defaultStakingSCBuild, stakingAccount, decodedParams, decoded.ExtraData))
+
+	expectedExtra, err := ValidatorsToIBFTExtra(stakingAccount)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedExtra, decoded.ExtraData)
+}
+
+func TestDevGenesisAccount(t *testing.T) {
+	account := DevGenesisAccount(addr1)
+
+	validators, err := DecodeValidators(account)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Address{addr1}, validators)
+
+	minCount, maxCount, err := GetValidatorBounds(account.Storage)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(1), minCount)
+	assert.GreaterOrEqual(t, maxCount, minCount)
+}
+
+func TestGenesisPatch(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	baseChain := &chain.Chain{
+		Name: "hand-maintained",
+		Genesis: &chain.Genesis{
+			GasLimit: testGenesisGasLimit,
+			Alloc: map[types.Address]*chain.GenesisAccount{
+				addr1: {Balance: big.NewInt(1000)},
+			},
+		},
+		Params: &chain.Params{
+			ChainID: testGenesisChainID,
+			Forks:   chain.AllForksEnabled,
+		},
+	}
+
+	baseData, err := json.Marshal(baseChain)
+	assert.NoError(t, err)
+
+	patchData, err := GenesisPatch([]types.Address{addr1, addr2}, params)
+	assert.NoError(t, err)
+
+	var base, patch interface{}
+
+	assert.NoError(t, json.Unmarshal(baseData, &base))
+	assert.NoError(t, json.Unmarshal(patchData, &patch))
+
+	patchedData, err := json.Marshal(applyMergePatch(base, patch))
+	assert.NoError(t, err)
+
+	patchedChain := &chain.Chain{}
+	assert.NoError(t, json.Unmarshal(patchedData, patchedChain))
+
+	expectedAccount, err := PredeployStakingSC([]types.Address{addr1, addr2}, params)
+	assert.NoError(t, err)
+
+	// The staking SC account is added, and the rest of the genesis is kept
+	assert.Equal(t, expectedAccount, patchedChain.Genesis.Alloc[stakingContracts.AddrStakingContract])
+	assert.Equal(t, big.NewInt(1000), patchedChain.Genesis.Alloc[addr1].Balance)
+	assert.Equal(t, baseChain.Name, patchedChain.Name)
+	assert.Equal(t, baseChain.Genesis.GasLimit, patchedChain.Genesis.GasLimit)
+	assert.Equal(t, baseChain.Params.ChainID, patchedChain.Params.ChainID)
+}
+
+// applyMergePatch applies the JSON merge patch to the target, as defined by RFC 7386
+func applyMergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+		} else {
+			targetObj[key] = applyMergePatch(targetObj[key], value)
+		}
+	}
+
+	return targetObj
+}
@@ -0,0 +1,38 @@
+package staking
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// gethGenesisAccount is the JSON encoding of an account in the geth genesis alloc
+type gethGenesisAccount struct {
+	Code    *string                   `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+	Balance string                    `json:"balance"`
+	Nonce   *string                   `json:"nonce,omitempty"`
+}
+
+// MarshalGethAlloc encodes the genesis account as a geth genesis alloc with the single account at addr.
+// Geth expects the alloc addresses without the 0x prefix, and the balance as a decimal string
+func MarshalGethAlloc(addr types.Address, account *chain.GenesisAccount) ([]byte, error) {
+	obj := gethGenesisAccount{
+		Storage: account.Storage,
+		Balance: accountBalance(account).String(),
+	}
+
+	if len(account.Code) != 0 {
+		obj.Code = types.EncodeBytes(account.Code)
+	}
+
+	if account.Nonce != 0 {
+		obj.Nonce = types.EncodeUint64(account.Nonce)
+	}
+
+	return json.Marshal(map[string]gethGenesisAccount{
+		strings.TrimPrefix(addr.String(), "0x"): obj,
+	})
+}
@@ -0,0 +1,52 @@
+package staking
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalGethAlloc(t *testing.T) {
+	account, err := PredeployStakingSC(
+		[]types.Address{addr1, addr2},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	data, err := MarshalGethAlloc(types.StringToAddress("1001"), account)
+	assert.NoError(t, err)
+
+	// Decode the output the same way geth decodes the genesis alloc
+	var alloc map[string]struct {
+		Code    string            `json:"code"`
+		Storage map[string]string `json:"storage"`
+		Balance string            `json:"balance"`
+		Nonce   string            `json:"nonce"`
+	}
+
+	assert.NoError(t, json.Unmarshal(data, &alloc))
+	assert.Len(t, alloc, 1)
+
+	gethAccount, ok := alloc["0000000000000000000000000000000000001001"]
+	assert.True(t, ok)
+
+	assert.Equal(t, hex.EncodeToHex(account.Code), gethAccount.Code)
+
+	// 2 validators with the default stake of 10 ETH
+	assert.Equal(t, "20000000000000000000", gethAccount.Balance)
+	balance, ok := new(big.Int).SetString(gethAccount.Balance, 10)
+	assert.True(t, ok)
+	assert.Equal(t, account.Balance, balance)
+
+	assert.Empty(t, gethAccount.Nonce)
+
+	assert.Len(t, gethAccount.Storage, len(account.Storage))
+
+	for key, value := range account.Storage {
+		assert.Equal(t, value.String(), gethAccount.Storage[key.String()])
+	}
+}
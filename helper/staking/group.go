@@ -0,0 +1,64 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrGroupsNotSupported = errors.New("staking SC doesn't support validator groups")
+	ErrInvalidGroupID     = errors.New("validator group ID is out of range")
+)
+
+// ValidatorGroup is a validator that belongs to a group (ex. a shard or a region)
+type ValidatorGroup struct {
+	Address types.Address
+	GroupID uint64
+}
+
+// PredeployStakingSCWithGroups is a helper method for setting up the staking smart contract account
+// of the given build, using the passed in validators as pre-staked validators.
+// The group ID of each registered validator, which must be lower than numGroups, is written to the
+// AddressToGroup mapping, so the build must support groups (ex. a build registered with RegisterStakingSCBuild)
+func PredeployStakingSCWithGroups(
+	build StakingSCBuild,
+	validators []ValidatorGroup,
+	numGroups uint64,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	addresses := make([]types.Address, len(validators))
+	groups := make(map[types.Address]types.Hash, len(validators))
+
+	for indx, validator := range validators {
+		if validator.GroupID >= numGroups {
+			return nil, fmt.Errorf(
+				"%w, validator %s, group %d, number of groups %d",
+				ErrInvalidGroupID,
+				validator.Address,
+				validator.GroupID,
+				numGroups,
+			)
+		}
+
+		addresses[indx] = validator.Address
+		groups[validator.Address] = types.BytesToHash(new(big.Int).SetUint64(validator.GroupID).Bytes())
+	}
+
+	stakedValidators, err := defaultStakedValidators(addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	return predeployStakingSCWithAddressMapping(
+		build,
+		stakedValidators,
+		params,
+		build.Slots.AddressToGroup,
+		ErrGroupsNotSupported,
+		groups,
+	)
+}
@@ -0,0 +1,69 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployStakingSCWithGroups(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+	validators := []ValidatorGroup{
+		{Address: addr1, GroupID: 1},
+		{Address: addr2, GroupID: 2},
+	}
+
+	build := defaultStakingSCBuild
+	build.Slots.AddressToGroup = &testMappingSlot
+
+	t.Run("should write the group IDs", func(t *testing.T) {
+		account, err := PredeployStakingSCWithGroups(build, validators, 3, params)
+		assert.NoError(t, err)
+
+		for _, validator := range validators {
+			assert.Equal(
+				t,
+				types.BytesToHash(new(big.Int).SetUint64(validator.GroupID).Bytes()),
+				account.Storage[testMappingKeys[validator.Address]],
+			)
+		}
+	})
+
+	t.Run("should not write the group IDs when staking is disabled", func(t *testing.T) {
+		disabledParams := params
+		disabledParams.DisableStaking = true
+
+		account, err := PredeployStakingSCWithGroups(build, validators, 3, disabledParams)
+		assert.NoError(t, err)
+
+		for _, validator := range validators {
+			assert.NotContains(t, account.Storage, testMappingKeys[validator.Address])
+		}
+	})
+
+	t.Run("should reject a group slot over a core slot", func(t *testing.T) {
+		collidingBuild := defaultStakingSCBuild
+		collidingSlot := addressToStakedAmountSlot
+		collidingBuild.Slots.AddressToGroup = &collidingSlot
+
+		_, err := PredeployStakingSCWithGroups(collidingBuild, validators, 3, params)
+		assert.ErrorIs(t, err, ErrSlotCollision)
+	})
+
+	t.Run("should fail for the embedded staking SC", func(t *testing.T) {
+		_, err := PredeployStakingSCWithGroups(defaultStakingSCBuild, validators, 3, params)
+		assert.ErrorIs(t, err, ErrGroupsNotSupported)
+	})
+
+	t.Run("should reject an out of range group ID", func(t *testing.T) {
+		_, err := PredeployStakingSCWithGroups(
+			build,
+			[]ValidatorGroup{{Address: addr1, GroupID: 3}},
+			3,
+			params,
+		)
+		assert.ErrorIs(t, err, ErrInvalidGroupID)
+	})
+}
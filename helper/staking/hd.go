@@ -0,0 +1,147 @@
+package staking
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	ErrInvalidMnemonic      = errors.New("mnemonic must be lowercase ASCII words separated by single spaces")
+	ErrInvalidDerivedKey    = errors.New("derived key is invalid for this index")
+	ErrInvalidValidatorsNum = errors.New("number of validators must be positive")
+)
+
+const (
+	// bip39Iterations is the number of PBKDF2 rounds for the BIP-39 seed
+	bip39Iterations = 2048
+
+	// bip32HardenedOffset is the first hardened child index
+	bip32HardenedOffset = uint32(0x80000000)
+)
+
+// testValidatorsPath is the BIP-44 path prefix of the Ethereum accounts, m/44'/60'/0'/0
+var testValidatorsPath = []uint32{
+	bip32HardenedOffset + 44,
+	bip32HardenedOffset + 60,
+	bip32HardenedOffset + 0,
+	0,
+}
+
+// DeriveTestValidators derives count validator addresses from the mnemonic,
+// using the BIP-44 Ethereum account path m/44'/60'/0'/0/i, so test networks get
+// the same validator set on every run. The addresses match the wallets that use the same mnemonic.
+//
+// The mnemonic checksum isn't validated, since it doesn't affect the derived seed.
+// Only lowercase ASCII mnemonics (ex. the English wordlist) are supported, since they don't change
+// under the NFKD normalization BIP-39 requires. The keys are not meant to hold funds
+func DeriveTestValidators(mnemonic string, count int) ([]types.Address, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("%w, got %d", ErrInvalidValidatorsNum, count)
+	}
+
+	if !isNormalizedMnemonic(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	// BIP-39 seed, with an empty passphrase
+	seed := pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"), bip39Iterations, 64, sha512.New)
+
+	// BIP-32 master key
+	key, chainCode := splitHMAC([]byte("Bitcoin seed"), seed)
+
+	var err error
+
+	for _, index := range testValidatorsPath {
+		if key, chainCode, err = deriveChildKey(key, chainCode, index); err != nil {
+			return nil, err
+		}
+	}
+
+	validators := make([]types.Address, count)
+
+	for indx := range validators {
+		childKey, _, err := deriveChildKey(key, chainCode, uint32(indx))
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive validator %d, %w", indx, err)
+		}
+
+		privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), childKey)
+		validators[indx] = crypto.PubKeyToAddress(&privateKey.ToECDSA().PublicKey)
+	}
+
+	return validators, nil
+}
+
+// isNormalizedMnemonic checks that the mnemonic is lowercase ASCII words separated by single spaces
+func isNormalizedMnemonic(mnemonic string) bool {
+	words := strings.Split(mnemonic, " ")
+	for _, word := range words {
+		if word == "" {
+			return false
+		}
+
+		for _, c := range word {
+			if c < 'a' || c > 'z' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// deriveChildKey derives the BIP-32 private child key at the index from the parent key
+func deriveChildKey(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+
+	if index >= bip32HardenedOffset {
+		// Hardened child: 0x00 . parent key . index
+		data = append([]byte{0x00}, key...)
+	} else {
+		// Normal child: compressed parent public key . index
+		_, publicKey := btcec.PrivKeyFromBytes(btcec.S256(), key)
+		data = publicKey.SerializeCompressed()
+	}
+
+	var indexBuf [4]byte
+
+	binary.BigEndian.PutUint32(indexBuf[:], index)
+	data = append(data, indexBuf[:]...)
+
+	childTweak, childChainCode := splitHMAC(chainCode, data)
+
+	curveOrder := btcec.S256().N
+
+	tweak := new(big.Int).SetBytes(childTweak)
+	if tweak.Cmp(curveOrder) >= 0 {
+		return nil, nil, fmt.Errorf("%w, index %d", ErrInvalidDerivedKey, index)
+	}
+
+	childKey := tweak.Add(tweak, new(big.Int).SetBytes(key))
+	childKey.Mod(childKey, curveOrder)
+
+	if childKey.Sign() == 0 {
+		return nil, nil, fmt.Errorf("%w, index %d", ErrInvalidDerivedKey, index)
+	}
+
+	return types.BytesToHash(childKey.Bytes()).Bytes(), childChainCode, nil
+}
+
+// splitHMAC returns the left and right halves of HMAC-SHA512(key, data)
+func splitHMAC(key, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	_, _ = mac.Write(data)
+	sum := mac.Sum(nil)
+
+	return sum[:32], sum[32:]
+}
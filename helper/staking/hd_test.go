@@ -0,0 +1,44 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeriveTestValidators(t *testing.T) {
+	// The default development mnemonic of the Hardhat and Foundry local networks
+	mnemonic := "test test test test test test test test test test test junk"
+
+	t.Run("should derive the wallet addresses", func(t *testing.T) {
+		validators, err := DeriveTestValidators(mnemonic, 2)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []types.Address{
+			types.StringToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+			types.StringToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		}, validators)
+	})
+
+	t.Run("should be deterministic", func(t *testing.T) {
+		validators, err := DeriveTestValidators(mnemonic, 5)
+		assert.NoError(t, err)
+
+		otherValidators, err := DeriveTestValidators(mnemonic, 5)
+		assert.NoError(t, err)
+
+		assert.Equal(t, validators, otherValidators)
+	})
+
+	t.Run("should reject invalid input", func(t *testing.T) {
+		_, err := DeriveTestValidators(mnemonic, 0)
+		assert.ErrorIs(t, err, ErrInvalidValidatorsNum)
+
+		_, err = DeriveTestValidators("test  test", 1)
+		assert.ErrorIs(t, err, ErrInvalidMnemonic)
+
+		_, err = DeriveTestValidators("Test test", 1)
+		assert.ErrorIs(t, err, ErrInvalidMnemonic)
+	})
+}
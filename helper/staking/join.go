@@ -0,0 +1,66 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrJoinBlocksNotSupported = errors.New("staking SC doesn't support validator join blocks")
+	ErrInvalidJoinBlock       = errors.New("validator join block is after the latest allowed join block")
+)
+
+// ValidatorJoin is a validator with the block number it joined the validator set at
+type ValidatorJoin struct {
+	Address   types.Address
+	JoinBlock uint64
+}
+
+// PredeployStakingSCWithJoinBlocks is a helper method for setting up the staking smart contract account
+// of the given build, using the passed in validators as pre-staked validators.
+// The join block of each registered validator, which must not be after maxJoinBlock, is written to the
+// AddressToJoinBlock mapping, so the build must account rewards since the join block
+// (ex. a build registered with RegisterStakingSCBuild). A maxJoinBlock of 0 requires all the validators
+// to join at genesis
+func PredeployStakingSCWithJoinBlocks(
+	build StakingSCBuild,
+	validators []ValidatorJoin,
+	maxJoinBlock uint64,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	addresses := make([]types.Address, len(validators))
+	joinBlocks := make(map[types.Address]types.Hash, len(validators))
+
+	for indx, validator := range validators {
+		if validator.JoinBlock > maxJoinBlock {
+			return nil, fmt.Errorf(
+				"%w, validator %s, join block %d, latest join block %d",
+				ErrInvalidJoinBlock,
+				validator.Address,
+				validator.JoinBlock,
+				maxJoinBlock,
+			)
+		}
+
+		addresses[indx] = validator.Address
+		joinBlocks[validator.Address] = types.BytesToHash(new(big.Int).SetUint64(validator.JoinBlock).Bytes())
+	}
+
+	stakedValidators, err := defaultStakedValidators(addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	return predeployStakingSCWithAddressMapping(
+		build,
+		stakedValidators,
+		params,
+		build.Slots.AddressToJoinBlock,
+		ErrJoinBlocksNotSupported,
+		joinBlocks,
+	)
+}
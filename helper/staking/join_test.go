@@ -0,0 +1,69 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployStakingSCWithJoinBlocks(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+	validators := []ValidatorJoin{
+		{Address: addr1, JoinBlock: 50},
+		{Address: addr2, JoinBlock: 100},
+	}
+
+	build := defaultStakingSCBuild
+	build.Slots.AddressToJoinBlock = &testMappingSlot
+
+	t.Run("should write the join blocks", func(t *testing.T) {
+		account, err := PredeployStakingSCWithJoinBlocks(build, validators, 100, params)
+		assert.NoError(t, err)
+
+		for _, validator := range validators {
+			assert.Equal(
+				t,
+				types.BytesToHash(new(big.Int).SetUint64(validator.JoinBlock).Bytes()),
+				account.Storage[testMappingKeys[validator.Address]],
+			)
+		}
+	})
+
+	t.Run("should not write the join blocks when staking is disabled", func(t *testing.T) {
+		disabledParams := params
+		disabledParams.DisableStaking = true
+
+		account, err := PredeployStakingSCWithJoinBlocks(build, validators, 100, disabledParams)
+		assert.NoError(t, err)
+
+		for _, validator := range validators {
+			assert.NotContains(t, account.Storage, testMappingKeys[validator.Address])
+		}
+	})
+
+	t.Run("should reject a join block slot over a core slot", func(t *testing.T) {
+		collidingBuild := defaultStakingSCBuild
+		collidingSlot := addressToValidatorIndexSlot
+		collidingBuild.Slots.AddressToJoinBlock = &collidingSlot
+
+		_, err := PredeployStakingSCWithJoinBlocks(collidingBuild, validators, 100, params)
+		assert.ErrorIs(t, err, ErrSlotCollision)
+	})
+
+	t.Run("should fail for the embedded staking SC", func(t *testing.T) {
+		_, err := PredeployStakingSCWithJoinBlocks(defaultStakingSCBuild, validators, 100, params)
+		assert.ErrorIs(t, err, ErrJoinBlocksNotSupported)
+	})
+
+	t.Run("should reject a join block after genesis", func(t *testing.T) {
+		_, err := PredeployStakingSCWithJoinBlocks(
+			build,
+			[]ValidatorJoin{{Address: addr1, JoinBlock: 1}},
+			0,
+			params,
+		)
+		assert.ErrorIs(t, err, ErrInvalidJoinBlock)
+	})
+}
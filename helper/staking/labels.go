@@ -0,0 +1,44 @@
+package staking
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const unknownStorageLabel = "unknown"
+
+// LabelStorageKey returns a human-readable label for a storage key of the
+// staking SC account (ex. validators[3], stakedAmount[0x...], minNumValidators).
+// The key is reverse-mapped by recomputing all the known storage indexes
+// for the passed in validators, so keys belonging to other addresses
+// are labeled as unknown
+func LabelStorageKey(key types.Hash, validators []types.Address) string {
+	switch key {
+	case SlotKey(validatorsSlot):
+		return "validators.length"
+	case SlotKey(stakedAmountSlot):
+		return "totalStakedAmount"
+	case SlotKey(minNumValidatorSlot):
+		return "minNumValidators"
+	case SlotKey(maxNumValidatorSlot):
+		return "maxNumValidators"
+	}
+
+	for indx, validator := range validators {
+		storageIndexes := getStorageIndexes(validator, int64(indx))
+
+		switch key {
+		case types.BytesToHash(storageIndexes.ValidatorsIndex):
+			return fmt.Sprintf("validators[%d]", indx)
+		case types.BytesToHash(storageIndexes.AddressToIsValidatorIndex):
+			return fmt.Sprintf("isValidator[%s]", validator)
+		case types.BytesToHash(storageIndexes.AddressToStakedAmountIndex):
+			return fmt.Sprintf("stakedAmount[%s]", validator)
+		case types.BytesToHash(storageIndexes.AddressToValidatorIndexIndex):
+			return fmt.Sprintf("validatorIndex[%s]", validator)
+		}
+	}
+
+	return unknownStorageLabel
+}
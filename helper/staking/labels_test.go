@@ -0,0 +1,73 @@
+package staking
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelStorageKey(t *testing.T) {
+	validators := []types.Address{addr1, addr2}
+	storageIndexes := getStorageIndexes(addr2, 1)
+
+	tests := []struct {
+		name     string
+		key      types.Hash
+		expected string
+	}{
+		{
+			name:     "validators array size",
+			key:      types.BytesToHash(big.NewInt(validatorsSlot).Bytes()),
+			expected: "validators.length",
+		},
+		{
+			name:     "total staked amount",
+			key:      types.BytesToHash(big.NewInt(stakedAmountSlot).Bytes()),
+			expected: "totalStakedAmount",
+		},
+		{
+			name:     "minimum number of validators",
+			key:      types.BytesToHash(big.NewInt(minNumValidatorSlot).Bytes()),
+			expected: "minNumValidators",
+		},
+		{
+			name:     "maximum number of validators",
+			key:      types.BytesToHash(big.NewInt(maxNumValidatorSlot).Bytes()),
+			expected: "maxNumValidators",
+		},
+		{
+			name:     "validators array element",
+			key:      types.BytesToHash(storageIndexes.ValidatorsIndex),
+			expected: "validators[1]",
+		},
+		{
+			name:     "is validator mapping",
+			key:      types.BytesToHash(storageIndexes.AddressToIsValidatorIndex),
+			expected: fmt.Sprintf("isValidator[%s]", addr2),
+		},
+		{
+			name:     "staked amount mapping",
+			key:      types.BytesToHash(storageIndexes.AddressToStakedAmountIndex),
+			expected: fmt.Sprintf("stakedAmount[%s]", addr2),
+		},
+		{
+			name:     "validator index mapping",
+			key:      types.BytesToHash(storageIndexes.AddressToValidatorIndexIndex),
+			expected: fmt.Sprintf("validatorIndex[%s]", addr2),
+		},
+		{
+			name:     "unrelated key",
+			key:      types.StringToHash("0xdeadbeef"),
+			expected: unknownStorageLabel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, LabelStorageKey(tt.key, validators))
+		})
+	}
+}
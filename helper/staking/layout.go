@@ -0,0 +1,178 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrSlotCollision = errors.New("staking SC state variables share a storage slot")
+
+// ReservedSlot is a storage slot reserved for a staking SC state variable
+type ReservedSlot struct {
+	Name string // name of the state variable
+	Slot int64  // storage slot
+	Type string // solidity type of the state variable
+}
+
+// String returns the description of the reserved slot, ex. _validators (slot 0, address[])
+func (r ReservedSlot) String() string {
+	return fmt.Sprintf("%s (slot %d, %s)", r.Name, r.Slot, r.Type)
+}
+
+// ReservedSlots returns the storage slots reserved by the embedded staking SC
+func ReservedSlots() []ReservedSlot {
+	return DefaultStorageSlots.ReservedSlots()
+}
+
+// ReservedSlots returns the storage slots reserved by the staking SC with this storage layout
+func (s StorageSlots) ReservedSlots() []ReservedSlot {
+	reservedSlots := []ReservedSlot{
+		{Name: "_validators", Slot: s.Validators, Type: "address[]"},
+		{Name: "_addressToIsValidator", Slot: s.AddressToIsValidator, Type: "mapping(address => bool)"},
+		{Name: "_addressToStakedAmount", Slot: s.AddressToStakedAmount, Type: "mapping(address => uint256)"},
+		{Name: "_addressToValidatorIndex", Slot: s.AddressToValidatorIndex, Type: "mapping(address => uint256)"},
+		{Name: "_stakedAmount", Slot: s.StakedAmount, Type: "uint256"},
+		{Name: "_minimumNumValidators", Slot: s.MinNumValidator, Type: "uint256"},
+		{Name: "_maximumNumValidators", Slot: s.MaxNumValidator, Type: "uint256"},
+	}
+
+	if s.Paused != nil {
+		reservedSlots = append(reservedSlots, ReservedSlot{Name: "_paused", Slot: *s.Paused, Type: "bool"})
+	}
+
+	if s.TotalValidators != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{Name: "_totalValidators", Slot: *s.TotalValidators, Type: "uint256"},
+		)
+	}
+
+	if s.RewardPerBlock != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{Name: "_rewardPerBlock", Slot: *s.RewardPerBlock, Type: "uint256"},
+		)
+	}
+
+	if s.EpochSize != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{Name: "_epochSize", Slot: *s.EpochSize, Type: "uint256"},
+		)
+	}
+
+	if s.Token != nil {
+		reservedSlots = append(reservedSlots, ReservedSlot{Name: "_token", Slot: *s.Token, Type: "address"})
+	}
+
+	if s.ValidatorsRoot != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{Name: "_validatorsRoot", Slot: *s.ValidatorsRoot, Type: "bytes32"},
+		)
+	}
+
+	if s.AddressToCommission != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{
+				Name: "_addressToCommission",
+				Slot: *s.AddressToCommission,
+				Type: "mapping(address => uint256)",
+			},
+		)
+	}
+
+	if s.AddressToUnlockTime != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{
+				Name: "_addressToUnlockTime",
+				Slot: *s.AddressToUnlockTime,
+				Type: "mapping(address => uint256)",
+			},
+		)
+	}
+
+	if s.AddressToWithdrawal != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{
+				Name: "_addressToWithdrawal",
+				Slot: *s.AddressToWithdrawal,
+				Type: "mapping(address => address)",
+			},
+		)
+	}
+
+	if s.AddressToGroup != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{
+				Name: "_addressToGroup",
+				Slot: *s.AddressToGroup,
+				Type: "mapping(address => uint256)",
+			},
+		)
+	}
+
+	if s.AddressToJoinBlock != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{
+				Name: "_addressToJoinBlock",
+				Slot: *s.AddressToJoinBlock,
+				Type: "mapping(address => uint256)",
+			},
+		)
+	}
+
+	if s.Delegations != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{
+				Name: "_delegations",
+				Slot: *s.Delegations,
+				Type: "mapping(address => mapping(address => uint256))",
+			},
+		)
+	}
+
+	if s.ScheduledSets != nil {
+		reservedSlots = append(
+			reservedSlots,
+			ReservedSlot{
+				Name: "_scheduledSets",
+				Slot: *s.ScheduledSets,
+				Type: "mapping(uint256 => address[])",
+			},
+		)
+	}
+
+	return reservedSlots
+}
+
+// checkSlotCollisions checks that no two state variables of the storage layout share a slot,
+// since a feature slot set over another slot (ex. over the staked amount mapping)
+// would silently overwrite the values written for it
+func (s StorageSlots) checkSlotCollisions() error {
+	used := make(map[int64]string)
+
+	for _, reservedSlot := range s.ReservedSlots() {
+		// A fixed validators array takes one slot per element
+		span := int64(1)
+		if reservedSlot.Slot == s.Validators && s.ValidatorsKind == FixedArray && s.ValidatorsCapacity > 0 {
+			span = s.ValidatorsCapacity
+		}
+
+		for slot := reservedSlot.Slot; slot < reservedSlot.Slot+span; slot++ {
+			if name, ok := used[slot]; ok {
+				return fmt.Errorf("%w, %s and %s use slot %d", ErrSlotCollision, name, reservedSlot.Name, slot)
+			}
+
+			used[slot] = reservedSlot.Name
+		}
+	}
+
+	return nil
+}
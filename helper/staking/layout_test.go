@@ -0,0 +1,90 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservedSlots(t *testing.T) {
+	reservedSlots := ReservedSlots()
+
+	names := make([]string, len(reservedSlots))
+	for indx, reservedSlot := range reservedSlots {
+		names[indx] = reservedSlot.Name
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			"_validators",
+			"_addressToIsValidator",
+			"_addressToStakedAmount",
+			"_addressToValidatorIndex",
+			"_stakedAmount",
+			"_minimumNumValidators",
+			"_maximumNumValidators",
+		},
+		names,
+	)
+	assert.Equal(t, "_validators (slot 0, address[])", reservedSlots[0].String())
+	assert.Equal(t, maxNumValidatorSlot, reservedSlots[6].Slot)
+
+	pausedSlot := int64(7)
+	slots := DefaultStorageSlots
+	slots.Paused = &pausedSlot
+
+	pausableSlots := slots.ReservedSlots()
+	assert.Len(t, pausableSlots, len(reservedSlots)+1)
+	assert.Equal(t, "_paused (slot 7, bool)", pausableSlots[len(pausableSlots)-1].String())
+}
+
+func TestCheckSlotCollisions(t *testing.T) {
+	coreSlot := addressToStakedAmountSlot
+	freeSlot := int64(7)
+
+	tests := []struct {
+		name        string
+		slots       func() StorageSlots
+		expectedErr error
+	}{
+		{"embedded layout", func() StorageSlots { return DefaultStorageSlots }, nil},
+		{
+			"optional slot after the core slots",
+			func() StorageSlots {
+				slots := DefaultStorageSlots
+				slots.Paused = &freeSlot
+
+				return slots
+			},
+			nil,
+		},
+		{
+			"optional slot over a core slot",
+			func() StorageSlots {
+				slots := DefaultStorageSlots
+				slots.AddressToUnlockTime = &coreSlot
+
+				return slots
+			},
+			ErrSlotCollision,
+		},
+		{
+			"fixed validators array over the following slots",
+			func() StorageSlots {
+				slots := DefaultStorageSlots
+				slots.ValidatorsKind = FixedArray
+				slots.ValidatorsCapacity = 2
+
+				return slots
+			},
+			ErrSlotCollision,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.slots().checkSlotCollisions(), tt.expectedErr)
+		})
+	}
+}
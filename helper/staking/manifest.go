@@ -0,0 +1,120 @@
+package staking
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrManifestMismatch = errors.New("staking SC account doesn't match the manifest")
+)
+
+// Manifest is a machine-readable description of the predeployed staking SC account
+type Manifest struct {
+	ContractAddress   types.Address       `json:"contractAddress"`
+	CodeHash          types.Hash          `json:"codeHash"`
+	Validators        []ManifestValidator `json:"validators"`
+	MinValidatorCount uint64              `json:"minValidatorCount"`
+	MaxValidatorCount uint64              `json:"maxValidatorCount"`
+	ContentHash       types.Hash          `json:"contentHash"`
+}
+
+// ManifestValidator is a pre-staked validator in the manifest
+type ManifestValidator struct {
+	Address types.Address `json:"address"`
+	Stake   string        `json:"stake"` // hex encoded, in wei
+}
+
+// PredeployManifest predeploys the staking SC with the passed in validators,
+// and returns the JSON manifest describing the predeployed account
+func PredeployManifest(validators []types.Address, params PredeployParams) ([]byte, error) {
+	stakingAccount, err := PredeployStakingSC(validators, params)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := newManifest(stakingAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(manifest, "", "  ")
+}
+
+// VerifyAgainstManifest checks that the validators, stakes, validator bounds, code hash
+// and content hash of the staking SC account match the ones of the JSON manifest
+func VerifyAgainstManifest(account *chain.GenesisAccount, manifest []byte) error {
+	expected := &Manifest{}
+	if err := json.Unmarshal(manifest, expected); err != nil {
+		return fmt.Errorf("unable to parse manifest, %w", err)
+	}
+
+	actual, err := newManifest(account)
+	if err != nil {
+		return err
+	}
+
+	mismatch := func(field string, expectedValue, actualValue interface{}) error {
+		return fmt.Errorf("%w, %s: expected %v, got %v", ErrManifestMismatch, field, expectedValue, actualValue)
+	}
+
+	if !reflect.DeepEqual(expected.Validators, actual.Validators) {
+		return mismatch("validators", expected.Validators, actual.Validators)
+	}
+
+	if expected.MinValidatorCount != actual.MinValidatorCount {
+		return mismatch("minValidatorCount", expected.MinValidatorCount, actual.MinValidatorCount)
+	}
+
+	if expected.MaxValidatorCount != actual.MaxValidatorCount {
+		return mismatch("maxValidatorCount", expected.MaxValidatorCount, actual.MaxValidatorCount)
+	}
+
+	if expected.CodeHash != actual.CodeHash {
+		return mismatch("codeHash", expected.CodeHash, actual.CodeHash)
+	}
+
+	if expected.ContentHash != actual.ContentHash {
+		return mismatch("contentHash", expected.ContentHash, actual.ContentHash)
+	}
+
+	return nil
+}
+
+// newManifest returns the manifest describing the staking SC account
+func newManifest(account *chain.GenesisAccount) (*Manifest, error) {
+	stakedValidators, err := decodeStakedValidators(account)
+	if err != nil {
+		return nil, err
+	}
+
+	minCount, maxCount, err := GetValidatorBounds(account.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		ContractAddress:   stakingContracts.AddrStakingContract,
+		CodeHash:          types.BytesToHash(keccak.Keccak256(nil, account.Code)),
+		Validators:        make([]ManifestValidator, len(stakedValidators)),
+		MinValidatorCount: minCount,
+		MaxValidatorCount: maxCount,
+		ContentHash:       GenesisContentHash(account),
+	}
+
+	for indx, validator := range stakedValidators {
+		manifest.Validators[indx] = ManifestValidator{
+			Address: validator.address,
+			Stake:   *types.EncodeBigInt(validator.stake),
+		}
+	}
+
+	return manifest, nil
+}
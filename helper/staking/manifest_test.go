@@ -0,0 +1,78 @@
+package staking
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployManifest(t *testing.T) {
+	validators := []types.Address{addr1, addr2}
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	data, err := PredeployManifest(validators, params)
+	assert.NoError(t, err)
+
+	var manifest Manifest
+
+	assert.NoError(t, json.Unmarshal(data, &manifest))
+
+	account, err := PredeployStakingSC(validators, params)
+	assert.NoError(t, err)
+
+	assert.Equal(t, stakingContracts.AddrStakingContract, manifest.ContractAddress)
+	assert.Equal(t, types.BytesToHash(keccak.Keccak256(nil, account.Code)), manifest.CodeHash)
+	assert.Equal(t, []ManifestValidator{
+		{Address: addr1, Stake: "0x8ac7230489e80000"},
+		{Address: addr2, Stake: "0x8ac7230489e80000"},
+	}, manifest.Validators)
+	assert.Equal(t, uint64(1), manifest.MinValidatorCount)
+	assert.Equal(t, uint64(10), manifest.MaxValidatorCount)
+	assert.Equal(t, GenesisContentHash(account), manifest.ContentHash)
+}
+
+func TestVerifyAgainstManifest(t *testing.T) {
+	validators := []types.Address{addr1, addr2}
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	manifest, err := PredeployManifest(validators, params)
+	assert.NoError(t, err)
+
+	t.Run("should accept a matching account", func(t *testing.T) {
+		account, err := PredeployStakingSC(validators, params)
+		assert.NoError(t, err)
+
+		assert.NoError(t, VerifyAgainstManifest(account, manifest))
+	})
+
+	t.Run("should detect a mutated stake", func(t *testing.T) {
+		account, err := PredeployStakingSC(validators, params)
+		assert.NoError(t, err)
+
+		account.Storage[types.BytesToHash(getStorageIndexes(addr2, 1).AddressToStakedAmountIndex)] =
+			types.BytesToHash(big.NewInt(1).Bytes())
+
+		assert.ErrorIs(t, VerifyAgainstManifest(account, manifest), ErrManifestMismatch)
+	})
+
+	t.Run("should detect mutated bounds", func(t *testing.T) {
+		account, err := PredeployStakingSC(validators, params)
+		assert.NoError(t, err)
+
+		SetValidatorBounds(account.Storage, 2, 10)
+
+		assert.ErrorIs(t, VerifyAgainstManifest(account, manifest), ErrManifestMismatch)
+	})
+
+	t.Run("should fail for an invalid manifest", func(t *testing.T) {
+		account, err := PredeployStakingSC(validators, params)
+		assert.NoError(t, err)
+
+		assert.Error(t, VerifyAgainstManifest(account, []byte("{")))
+	})
+}
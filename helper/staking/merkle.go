@@ -0,0 +1,96 @@
+package staking
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrValidatorsRootNotSupported = errors.New("staking SC doesn't store the validators Merkle root")
+
+// ValidatorsMerkleRoot returns the root of the Merkle tree of the validator set, built as follows:
+//
+//   - each leaf is keccak(address) of a validator, in the validators array order
+//   - each parent node is keccak(a . b) of its 2 children, with a the lower of the 2 hashes,
+//     so a membership proof doesn't need to record the side of each sibling
+//   - a node without a sibling is moved up to the next level unchanged
+//
+// The root of an empty validator set is the zero hash.
+// The scheme matches the OpenZeppelin MerkleProof library, so the membership proofs can be verified on chain
+func ValidatorsMerkleRoot(validators []types.Address) types.Hash {
+	if len(validators) == 0 {
+		return types.ZeroHash
+	}
+
+	level := make([][]byte, len(validators))
+	for indx, validator := range validators {
+		level[indx] = Hasher(validator.Bytes())
+	}
+
+	for len(level) > 1 {
+		nextLevel := make([][]byte, 0, (len(level)+1)/2)
+
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				nextLevel = append(nextLevel, level[i])
+
+				continue
+			}
+
+			nextLevel = append(nextLevel, hashSortedPair(level[i], level[i+1]))
+		}
+
+		level = nextLevel
+	}
+
+	return types.BytesToHash(level[0])
+}
+
+// hashSortedPair returns keccak(a . b), with the 2 hashes in ascending order
+func hashSortedPair(a, b []byte) []byte {
+	if bytes.Compare(a, b) > 0 {
+		a, b = b, a
+	}
+
+	return Hasher(append(append(make([]byte, 0, len(a)+len(b)), a...), b...))
+}
+
+// PredeployStakingSCWithMerkleRoot is a helper method for setting up the staking smart contract account
+// of the given build, using the passed in validators as pre-staked validators.
+// The Merkle root of the registered validator set, in the validators array order, is written to the
+// ValidatorsRoot slot, so light clients can prove the validator set membership.
+// The build must store the root (ex. a build registered with RegisterStakingSCBuild)
+func PredeployStakingSCWithMerkleRoot(
+	build StakingSCBuild,
+	validators []types.Address,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	if build.Slots.ValidatorsRoot == nil {
+		return nil, ErrValidatorsRootNotSupported
+	}
+
+	stakedValidators, err := defaultStakedValidators(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	stakingAccount, err := predeployStakingSC(build, stakedValidators, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// The root is built from the validators written to the validators array, in the array order
+	registered := registeredValidators(stakedValidators, params)
+
+	addresses := make([]types.Address, len(registered))
+	for indx, validator := range registered {
+		addresses[indx] = validator.address
+	}
+
+	// Set the value for the validators Merkle root
+	stakingAccount.Storage[SlotKey(*build.Slots.ValidatorsRoot)] = ValidatorsMerkleRoot(addresses)
+
+	return stakingAccount, nil
+}
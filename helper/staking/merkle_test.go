@@ -0,0 +1,95 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatorsMerkleRoot(t *testing.T) {
+	addr3 := types.StringToAddress("3")
+
+	leaf := func(address types.Address) []byte {
+		return keccak.Keccak256(nil, address.Bytes())
+	}
+
+	node := func(a, b []byte) []byte {
+		if types.BytesToHash(a).String() > types.BytesToHash(b).String() {
+			a, b = b, a
+		}
+
+		return keccak.Keccak256(nil, append(append([]byte{}, a...), b...))
+	}
+
+	tests := []struct {
+		name       string
+		validators []types.Address
+		expected   types.Hash
+	}{
+		{"no validators", []types.Address{}, types.ZeroHash},
+		{"single validator", []types.Address{addr1}, types.BytesToHash(leaf(addr1))},
+		{
+			"two validators",
+			[]types.Address{addr1, addr2},
+			types.BytesToHash(node(leaf(addr1), leaf(addr2))),
+		},
+		{
+			"odd number of validators",
+			[]types.Address{addr1, addr2, addr3},
+			types.BytesToHash(node(node(leaf(addr1), leaf(addr2)), leaf(addr3))),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ValidatorsMerkleRoot(tt.validators))
+		})
+	}
+}
+
+func TestPredeployStakingSCWithMerkleRoot(t *testing.T) {
+	rootSlot := int64(7)
+	build := defaultStakingSCBuild
+	build.Slots.ValidatorsRoot = &rootSlot
+
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	validators := []types.Address{addr2, addr1}
+
+	t.Run("should write the root in the array order", func(t *testing.T) {
+		account, err := PredeployStakingSCWithMerkleRoot(build, validators, params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ValidatorsMerkleRoot([]types.Address{addr2, addr1}), account.Storage[SlotKey(rootSlot)])
+	})
+
+	t.Run("should write the root of the sorted validators", func(t *testing.T) {
+		sortedParams := params
+		sortedParams.SortValidators = true
+
+		account, err := PredeployStakingSCWithMerkleRoot(build, validators, sortedParams)
+		assert.NoError(t, err)
+
+		decoded, err := DecodeValidators(account)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ValidatorsMerkleRoot(decoded), account.Storage[SlotKey(rootSlot)])
+	})
+
+	t.Run("should write the empty root when staking is disabled", func(t *testing.T) {
+		disabledParams := params
+		disabledParams.DisableStaking = true
+
+		account, err := PredeployStakingSCWithMerkleRoot(build, validators, disabledParams)
+		assert.NoError(t, err)
+
+		assert.Equal(t, types.ZeroHash, account.Storage[SlotKey(rootSlot)])
+	})
+
+	t.Run("should fail for the embedded staking SC", func(t *testing.T) {
+		_, err := PredeployStakingSCWithMerkleRoot(defaultStakingSCBuild, validators, params)
+		assert.ErrorIs(t, err, ErrValidatorsRootNotSupported)
+	})
+}
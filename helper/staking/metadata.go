@@ -0,0 +1,170 @@
+package staking
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoBytecodeMetadata      = errors.New("bytecode has no metadata")
+	ErrInvalidBytecodeMetadata = errors.New("invalid bytecode metadata")
+)
+
+// BytecodeMetadata is the compiler metadata solc appends to the runtime bytecode
+//
+// More information:
+// https://docs.soliditylang.org/en/latest/metadata.html#encoding-of-the-metadata-hash-in-the-bytecode
+type BytecodeMetadata struct {
+	Compiler string // compiler name, ex. solc
+	Version  string // compiler version, ex. 0.8.7
+	IPFSHash []byte // IPFS hash of the metadata JSON, if present
+}
+
+// cbor major types used in the metadata
+const (
+	cborByteString = 2
+	cborTextString = 3
+	cborMap        = 5
+)
+
+// ParseBytecodeMetadata parses the CBOR encoded compiler metadata at the end of the runtime bytecode.
+// The last 2 bytes of the bytecode are the big-endian length of the CBOR data preceding them
+func ParseBytecodeMetadata(code []byte) (*BytecodeMetadata, error) {
+	if len(code) < 2 {
+		return nil, ErrNoBytecodeMetadata
+	}
+
+	metadataLen := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if metadataLen == 0 || metadataLen > len(code)-2 {
+		return nil, ErrNoBytecodeMetadata
+	}
+
+	reader := &cborReader{data: code[len(code)-2-metadataLen : len(code)-2]}
+
+	numEntries, err := reader.readHeader(cborMap)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &BytecodeMetadata{}
+
+	for i := 0; i < numEntries; i++ {
+		key, err := reader.readBytes(cborTextString)
+		if err != nil {
+			return nil, err
+		}
+
+		majorType, err := reader.peekMajorType()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := reader.readBytes(majorType)
+		if err != nil {
+			return nil, err
+		}
+
+		switch string(key) {
+		case "solc":
+			metadata.Compiler = "solc"
+
+			// Release versions are encoded as 3 bytes, and prerelease versions as a string
+			if majorType == cborByteString && len(value) == 3 {
+				metadata.Version = fmt.Sprintf("%d.%d.%d", value[0], value[1], value[2])
+			} else {
+				metadata.Version = string(value)
+			}
+		case "ipfs":
+			metadata.IPFSHash = value
+		}
+	}
+
+	return metadata, nil
+}
+
+// codeWithoutMetadata returns the code up to the compiler metadata, if any, since the metadata
+// and anything after it are never executed. The metadata is usually at the end of the code,
+// but creation code can be followed by the constructor arguments, so it is also looked up
+// backwards from the end, where it must name the compiler to tell it apart from the arguments
+func codeWithoutMetadata(code []byte) []byte {
+	if _, err := ParseBytecodeMetadata(code); err == nil {
+		return code[:len(code)-2-int(binary.BigEndian.Uint16(code[len(code)-2:]))]
+	}
+
+	for end := len(code) - 1; end > 2; end-- {
+		metadata, err := ParseBytecodeMetadata(code[:end])
+		if err != nil || metadata.Compiler == "" {
+			continue
+		}
+
+		return code[:end-2-int(binary.BigEndian.Uint16(code[end-2:end]))]
+	}
+
+	return code
+}
+
+// cborReader reads the subset of CBOR used by the solc metadata
+type cborReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *cborReader) peekMajorType() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, ErrInvalidBytecodeMetadata
+	}
+
+	return r.data[r.pos] >> 5, nil
+}
+
+// readHeader reads the header of an item with the expected major type, and returns its length
+func (r *cborReader) readHeader(expectedType byte) (int, error) {
+	majorType, err := r.peekMajorType()
+	if err != nil {
+		return 0, err
+	}
+
+	if majorType != expectedType {
+		return 0, fmt.Errorf("%w: unexpected type %d", ErrInvalidBytecodeMetadata, majorType)
+	}
+
+	info := r.data[r.pos] & 0x1f
+	r.pos++
+
+	switch {
+	case info < 24:
+		return int(info), nil
+	case info == 24 && r.pos+1 <= len(r.data):
+		r.pos++
+
+		return int(r.data[r.pos-1]), nil
+	case info == 25 && r.pos+2 <= len(r.data):
+		r.pos += 2
+
+		return int(binary.BigEndian.Uint16(r.data[r.pos-2 : r.pos])), nil
+	default:
+		return 0, ErrInvalidBytecodeMetadata
+	}
+}
+
+// readBytes reads a byte or text string with the expected major type
+func (r *cborReader) readBytes(expectedType byte) ([]byte, error) {
+	if expectedType != cborByteString && expectedType != cborTextString {
+		return nil, fmt.Errorf("%w: unexpected type %d", ErrInvalidBytecodeMetadata, expectedType)
+	}
+
+	length, err := r.readHeader(expectedType)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.pos+length > len(r.data) {
+		return nil, ErrInvalidBytecodeMetadata
+	}
+
+	value := r.data[r.pos : r.pos+length]
+	r.pos += length
+
+	return value, nil
+}
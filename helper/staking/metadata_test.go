@@ -0,0 +1,34 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBytecodeMetadata(t *testing.T) {
+	t.Run("should parse the embedded staking SC metadata", func(t *testing.T) {
+		metadata, err := ParseBytecodeMetadata(hex.MustDecodeHex(StakingSCBytecode))
+		assert.NoError(t, err)
+
+		assert.Equal(t, "solc", metadata.Compiler)
+		assert.Equal(t, DefaultSolcVersion, metadata.Version)
+		assert.Equal(
+			t,
+			hex.MustDecodeHex("0x12208a8aa21d6df01384c9fc6d39a32e52ef1c0d18fd3bf9e2fca6ae1cae3d412688"),
+			metadata.IPFSHash,
+		)
+	})
+
+	t.Run("should fail for bytecode without metadata", func(t *testing.T) {
+		_, err := ParseBytecodeMetadata(hex.MustDecodeHex("0x6080604052600080fd0000"))
+		assert.ErrorIs(t, err, ErrNoBytecodeMetadata)
+	})
+
+	t.Run("should fail for malformed metadata", func(t *testing.T) {
+		// The length points to a CBOR text string instead of a map
+		_, err := ParseBytecodeMetadata(hex.MustDecodeHex("0x6080604052626869000003"))
+		assert.ErrorIs(t, err, ErrInvalidBytecodeMetadata)
+	})
+}
@@ -0,0 +1,78 @@
+package staking
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-multierror"
+)
+
+// PredeploySpec describes a staking SC account to predeploy
+type PredeploySpec struct {
+	SolcVersion string // solc version of the staking SC build, DefaultSolcVersion if empty
+	Validators  []types.Address
+	Params      PredeployParams
+}
+
+// predeploy generates the staking SC account described by the spec
+func (s PredeploySpec) predeploy() (*chain.GenesisAccount, error) {
+	version := s.SolcVersion
+	if version == "" {
+		version = DefaultSolcVersion
+	}
+
+	return PredeployStakingSCForSolc(version, s.Validators, s.Params)
+}
+
+// PredeployContractsParallel generates the accounts for the passed in specs
+// using the given number of worker goroutines.
+// The accounts are returned in the same order as the specs, and the errors
+// of all the failed specs are aggregated into the returned error
+func PredeployContractsParallel(specs []PredeploySpec, workers int) ([]*chain.GenesisAccount, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		accounts = make([]*chain.GenesisAccount, len(specs))
+		errs     = make([]error, len(specs))
+		jobs     = make(chan int)
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// Each worker writes only to the indexes it receives
+			for indx := range jobs {
+				accounts[indx], errs[indx] = specs[indx].predeploy()
+			}
+		}()
+	}
+
+	for indx := range specs {
+		jobs <- indx
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	var result error
+
+	for indx, err := range errs {
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("spec %d: %w", indx, err))
+		}
+	}
+
+	if result != nil {
+		return nil, result
+	}
+
+	return accounts, nil
+}
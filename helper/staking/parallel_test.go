@@ -0,0 +1,89 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPredeploySpecs(n int) []PredeploySpec {
+	specs := make([]PredeploySpec, n)
+
+	for indx := range specs {
+		validators := make([]types.Address, indx+1)
+		for i := range validators {
+			validators[i] = types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes())
+		}
+
+		specs[indx] = PredeploySpec{
+			Validators: validators,
+			Params: PredeployParams{
+				MinValidatorCount: 1,
+				MaxValidatorCount: uint64(indx + 1),
+			},
+		}
+	}
+
+	return specs
+}
+
+func predeployContractsSequential(specs []PredeploySpec) ([]*chain.GenesisAccount, error) {
+	accounts := make([]*chain.GenesisAccount, len(specs))
+
+	for indx, spec := range specs {
+		account, err := spec.predeploy()
+		if err != nil {
+			return nil, err
+		}
+
+		accounts[indx] = account
+	}
+
+	return accounts, nil
+}
+
+func TestPredeployContractsParallel(t *testing.T) {
+	specs := testPredeploySpecs(8)
+
+	t.Run("should keep the order of the specs", func(t *testing.T) {
+		expected, err := predeployContractsSequential(specs)
+		assert.NoError(t, err)
+
+		accounts, err := PredeployContractsParallel(specs, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, accounts)
+	})
+
+	t.Run("should aggregate the errors", func(t *testing.T) {
+		failingSpecs := append([]PredeploySpec{}, specs...)
+		failingSpecs[2].SolcVersion = "0.4.0"
+		failingSpecs[5].Params.Frozen = true
+
+		_, err := PredeployContractsParallel(failingSpecs, 3)
+		assert.ErrorIs(t, err, ErrUnknownSolcVersion)
+		assert.ErrorIs(t, err, ErrPausingNotSupported)
+	})
+}
+
+func BenchmarkPredeployContractsSequential(b *testing.B) {
+	specs := testPredeploySpecs(32)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := predeployContractsSequential(specs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPredeployContractsParallel(b *testing.B) {
+	specs := testPredeploySpecs(32)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := PredeployContractsParallel(specs, 4); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
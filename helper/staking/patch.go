@@ -0,0 +1,58 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrNegativeTotalStake = errors.New("stake update results in a negative total staked amount")
+)
+
+// StakeUpdateWrites returns the storage writes that change the stake of the validator at addr
+// from oldStake to newStake, given the current total staked amount of the staking SC.
+// Only the staked amount of the validator and the total staked amount are written,
+// so large genesis files can be patched without regenerating the whole storage.
+// The staking SC balance is not part of the storage, and has to be updated separately
+func StakeUpdateWrites(addr types.Address, oldStake, newStake, totalStaked *big.Int) ([]StorageWrite, error) {
+	if oldStake.Sign() < 0 || newStake.Sign() < 0 || totalStaked.Sign() < 0 {
+		return nil, fmt.Errorf(
+			"%w, old stake %s, new stake %s, total staked %s",
+			ErrNegativeStake,
+			oldStake,
+			newStake,
+			totalStaked,
+		)
+	}
+
+	newTotal := new(big.Int).Sub(totalStaked, oldStake)
+	newTotal.Add(newTotal, newStake)
+
+	if newTotal.Sign() < 0 {
+		return nil, fmt.Errorf("%w, total %s", ErrNegativeTotalStake, newTotal)
+	}
+
+	encodedStake, err := encodeStake(newStake)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stake for validator %s, %w", addr, err)
+	}
+
+	encodedTotal, err := encodeStake(newTotal)
+	if err != nil {
+		return nil, fmt.Errorf("invalid total staked amount, %w", err)
+	}
+
+	return []StorageWrite{
+		{
+			Key:   types.BytesToHash(getAddressMapping(addr, addressToStakedAmountSlot)),
+			Value: encodedStake,
+		},
+		{
+			Key:   SlotKey(stakedAmountSlot),
+			Value: encodedTotal,
+		},
+	}, nil
+}
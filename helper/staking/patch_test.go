@@ -0,0 +1,68 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStakeUpdateWrites(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	t.Run("should match the regenerated storage", func(t *testing.T) {
+		account, err := predeployStakingSC(
+			defaultStakingSCBuild,
+			[]stakedValidator{
+				{address: addr1, stake: big.NewInt(100)},
+				{address: addr2, stake: big.NewInt(200)},
+			},
+			params,
+		)
+		assert.NoError(t, err)
+
+		expected, err := predeployStakingSC(
+			defaultStakingSCBuild,
+			[]stakedValidator{
+				{address: addr1, stake: big.NewInt(100)},
+				{address: addr2, stake: big.NewInt(50)},
+			},
+			params,
+		)
+		assert.NoError(t, err)
+
+		writes, err := StakeUpdateWrites(addr2, big.NewInt(200), big.NewInt(50), decodeTotalStakedAmount(account))
+		assert.NoError(t, err)
+		assert.Len(t, writes, 2)
+
+		for _, write := range writes {
+			account.Storage[write.Key] = write.Value
+		}
+
+		assert.Equal(t, expected.Storage, account.Storage)
+	})
+
+	t.Run("should reject a negative total", func(t *testing.T) {
+		_, err := StakeUpdateWrites(addr1, big.NewInt(200), big.NewInt(50), big.NewInt(100))
+		assert.ErrorIs(t, err, ErrNegativeTotalStake)
+	})
+
+	testTable := []struct {
+		name        string
+		oldStake    *big.Int
+		newStake    *big.Int
+		totalStaked *big.Int
+	}{
+		{"should reject a negative old stake", big.NewInt(-5), big.NewInt(0), big.NewInt(100)},
+		{"should reject a negative new stake", big.NewInt(0), big.NewInt(-5), big.NewInt(100)},
+		{"should reject a negative total staked", big.NewInt(0), big.NewInt(5), big.NewInt(-100)},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			writes, err := StakeUpdateWrites(addr1, testCase.oldStake, testCase.newStake, testCase.totalStaked)
+			assert.ErrorIs(t, err, ErrNegativeStake)
+			assert.Nil(t, writes)
+		})
+	}
+}
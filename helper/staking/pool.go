@@ -0,0 +1,37 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrInvalidRewardPoolAmount  = errors.New("reward pool amount must be positive")
+	ErrInvalidRewardPoolAddress = errors.New("reward pool address must be set, and differ from the staking SC address")
+)
+
+// PredeployRewardPool returns the genesis account of the reward pool at poolAddr, funded with amount.
+// The pool is a plain account with no code or storage, which keeps the reward funds
+// separate from the staked principal held by the staking SC. It is added to the alloc
+// next to the staking SC account:
+//
+//	alloc[stakingContracts.AddrStakingContract] = stakingAccount
+//	alloc[poolAddr] = poolAccount
+func PredeployRewardPool(amount *big.Int, poolAddr types.Address) (*chain.GenesisAccount, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%w, got %s", ErrInvalidRewardPoolAmount, amount)
+	}
+
+	if poolAddr == types.ZeroAddress || poolAddr == stakingContracts.AddrStakingContract {
+		return nil, fmt.Errorf("%w, got %s", ErrInvalidRewardPoolAddress, poolAddr)
+	}
+
+	return &chain.GenesisAccount{
+		Balance: new(big.Int).Set(amount),
+	}, nil
+}
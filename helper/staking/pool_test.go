@@ -0,0 +1,40 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployRewardPool(t *testing.T) {
+	poolAddr := types.StringToAddress("1020")
+
+	t.Run("should fund the pool account", func(t *testing.T) {
+		amount := big.NewInt(1e18)
+
+		account, err := PredeployRewardPool(amount, poolAddr)
+		assert.NoError(t, err)
+
+		assert.Equal(t, amount, account.Balance)
+		assert.Empty(t, account.Code)
+		assert.Empty(t, account.Storage)
+
+		// The balance is a copy of the amount
+		amount.SetInt64(0)
+		assert.Equal(t, big.NewInt(1e18), account.Balance)
+	})
+
+	t.Run("should reject invalid input", func(t *testing.T) {
+		_, err := PredeployRewardPool(big.NewInt(0), poolAddr)
+		assert.ErrorIs(t, err, ErrInvalidRewardPoolAmount)
+
+		_, err = PredeployRewardPool(big.NewInt(1), types.ZeroAddress)
+		assert.ErrorIs(t, err, ErrInvalidRewardPoolAddress)
+
+		_, err = PredeployRewardPool(big.NewInt(1), stakingContracts.AddrStakingContract)
+		assert.ErrorIs(t, err, ErrInvalidRewardPoolAddress)
+	})
+}
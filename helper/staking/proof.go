@@ -0,0 +1,318 @@
+package staking
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrInvalidStateProof    = errors.New("invalid staking SC storage proof")
+	ErrIncompleteStateProof = errors.New("staking SC storage proof doesn't cover a required slot")
+	ErrEmptyValidatorSet    = errors.New("proven staking SC has no validators")
+)
+
+// Number of items of the storage trie nodes
+const (
+	branchNodeItems = 17
+	shortNodeItems  = 2
+)
+
+// PredeployFromStateProof generates a fresh staking SC genesis account with the same validators, stakes
+// and validator bounds as the source chain staking SC, proven by a Merkle-Patricia proof of its storage.
+//
+// The proof is the RLP list [[key, ...], [node, ...]] of the proven storage slots and of the storage trie
+// nodes on their paths, ex. the storageProof nodes returned by eth_getProof on the source chain.
+// root is the storage root of the source staking SC account, which the operators get from a trusted source.
+// Each slot read to rebuild the validator set must be proven, either present or absent (zero) in the trie,
+// so the migrated validator set can't differ from the source one.
+// The proven validator bounds must be within the absolute validator count limits, and the proven validator set
+// must not be empty unless allowEmpty is set (ex. when the source chain staking SC has no validators yet)
+func PredeployFromStateProof(proof []byte, root types.Hash, allowEmpty bool) (*chain.GenesisAccount, error) {
+	source, proven, err := verifyStorageProof(proof, root)
+	if err != nil {
+		return nil, err
+	}
+
+	requireProven := func(keys ...[]byte) error {
+		for _, key := range keys {
+			if _, ok := proven[types.BytesToHash(key)]; !ok {
+				return fmt.Errorf("%w, slot %s", ErrIncompleteStateProof, types.BytesToHash(key))
+			}
+		}
+
+		return nil
+	}
+
+	if err := requireProven(
+		SlotKey(validatorsSlot).Bytes(),
+		SlotKey(stakedAmountSlot).Bytes(),
+		SlotKey(minNumValidatorSlot).Bytes(),
+		SlotKey(maxNumValidatorSlot).Bytes(),
+	); err != nil {
+		return nil, err
+	}
+
+	arraySize, err := decodeValidatorsArraySize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	for indx := 0; indx < arraySize; indx++ {
+		if err := requireProven(getArrayElementIndex(validatorsSlot, int64(indx))); err != nil {
+			return nil, err
+		}
+	}
+
+	validators, err := DecodeValidators(source)
+	if err != nil {
+		return nil, err
+	}
+
+	for indx, validator := range validators {
+		storageIndexes := getStorageIndexes(validator, int64(indx))
+
+		if err := requireProven(
+			storageIndexes.AddressToIsValidatorIndex,
+			storageIndexes.AddressToStakedAmountIndex,
+			storageIndexes.AddressToValidatorIndexIndex,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	stakedValidators, err := decodeSourceValidators(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source staking SC, %w", err)
+	}
+
+	minCount, maxCount, err := GetValidatorBounds(source.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source staking SC, %w", err)
+	}
+
+	if len(stakedValidators) == 0 && !allowEmpty {
+		return nil, ErrEmptyValidatorSet
+	}
+
+	params := PredeployParams{
+		MinValidatorCount: minCount,
+		MaxValidatorCount: maxCount,
+	}
+
+	if err := AssertBoundsWithinLimits(params); err != nil {
+		return nil, fmt.Errorf("invalid source staking SC, %w", err)
+	}
+
+	return predeployStakingSC(defaultStakingSCBuild, stakedValidators, params)
+}
+
+// verifyStorageProof verifies each proven slot of the proof against the storage root.
+// It returns an account with the proven storage, and the set of the proven slots
+func verifyStorageProof(proof []byte, root types.Hash) (*chain.GenesisAccount, map[types.Hash]struct{}, error) {
+	var parser fastrlp.Parser
+
+	v, err := parser.Parse(proof)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w, %v", ErrInvalidStateProof, err)
+	}
+
+	elems, err := v.GetElems()
+	if err != nil || len(elems) != 2 {
+		return nil, nil, fmt.Errorf("%w, expected the [keys, nodes] list", ErrInvalidStateProof)
+	}
+
+	keys, err := elems[0].GetElems()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w, keys, %v", ErrInvalidStateProof, err)
+	}
+
+	encodedNodes, err := elems[1].GetElems()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w, nodes, %v", ErrInvalidStateProof, err)
+	}
+
+	// The nodes are referenced by their hash
+	nodes := make(map[types.Hash][]byte, len(encodedNodes))
+
+	for _, encodedNode := range encodedNodes {
+		node, err := encodedNode.Bytes()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w, node, %v", ErrInvalidStateProof, err)
+		}
+
+		nodes[types.BytesToHash(keccak.Keccak256(nil, node))] = append([]byte{}, node...)
+	}
+
+	account := &chain.GenesisAccount{
+		Storage: make(map[types.Hash]types.Hash, len(keys)),
+	}
+	proven := make(map[types.Hash]struct{}, len(keys))
+
+	for _, keyValue := range keys {
+		key, err := keyValue.Bytes()
+		if err != nil || len(key) != types.HashLength {
+			return nil, nil, fmt.Errorf("%w, slot keys must be %d bytes", ErrInvalidStateProof, types.HashLength)
+		}
+
+		slot := types.BytesToHash(key)
+
+		value, err := proveStorageSlot(root, slot, nodes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w, slot %s, %v", ErrInvalidStateProof, slot, err)
+		}
+
+		account.Storage[slot] = value
+		proven[slot] = struct{}{}
+	}
+
+	return account, proven, nil
+}
+
+// proveStorageSlot walks the storage trie from the root down to the slot, using the proof nodes.
+// It returns the slot value, which is zero if the path shows the slot is not in the trie
+func proveStorageSlot(root types.Hash, slot types.Hash, nodes map[types.Hash][]byte) (types.Hash, error) {
+	if root == types.EmptyRootHash {
+		return types.ZeroHash, nil
+	}
+
+	// The storage trie keys are the hashed slots
+	path := bytesToNibbles(keccak.Keccak256(nil, slot.Bytes()))
+
+	nodeHash := root
+
+	var node *fastrlp.Value
+
+	for {
+		// Nodes shorter than a hash are embedded in their parent, the others are referenced by their hash
+		if node == nil {
+			encodedNode, ok := nodes[nodeHash]
+			if !ok {
+				return types.ZeroHash, fmt.Errorf("missing node %s", nodeHash)
+			}
+
+			parsedNode, err := new(fastrlp.Parser).Parse(encodedNode)
+			if err != nil {
+				return types.ZeroHash, err
+			}
+
+			node = parsedNode
+		}
+
+		var child *fastrlp.Value
+
+		switch node.Elems() {
+		case branchNodeItems:
+			// The storage trie keys have the same length, so no value is stored in a branch node
+			if len(path) == 0 {
+				return types.ZeroHash, errors.New("path ends in a branch node")
+			}
+
+			child = node.Get(int(path[0]))
+			path = path[1:]
+
+		case shortNodeItems:
+			compactKey, err := node.Get(0).Bytes()
+			if err != nil {
+				return types.ZeroHash, err
+			}
+
+			key, isLeaf := decodeCompactKey(compactKey)
+
+			if !bytes.HasPrefix(path, key) || (isLeaf && len(path) != len(key)) {
+				// The path diverges from the trie, so the slot is not in the trie
+				return types.ZeroHash, nil
+			}
+
+			path = path[len(key):]
+
+			if isLeaf {
+				return decodeProvenValue(node.Get(1))
+			}
+
+			child = node.Get(1)
+
+		default:
+			return types.ZeroHash, fmt.Errorf("node has %d items", node.Elems())
+		}
+
+		if child.Type() == fastrlp.TypeArray {
+			node = child
+
+			continue
+		}
+
+		childHash, err := child.Bytes()
+		if err != nil {
+			return types.ZeroHash, err
+		}
+
+		switch len(childHash) {
+		case 0:
+			// Empty branch, so the slot is not in the trie
+			return types.ZeroHash, nil
+		case types.HashLength:
+			nodeHash = types.BytesToHash(childHash)
+			node = nil
+		default:
+			return types.ZeroHash, fmt.Errorf("invalid node reference of %d bytes", len(childHash))
+		}
+	}
+}
+
+// decodeProvenValue decodes the storage value of a leaf, which is RLP encoded without the leading zeroes
+func decodeProvenValue(leafValue *fastrlp.Value) (types.Hash, error) {
+	encodedValue, err := leafValue.Bytes()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	v, err := new(fastrlp.Parser).Parse(encodedValue)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	value, err := v.Bytes()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if len(value) > types.HashLength {
+		return types.ZeroHash, fmt.Errorf("value is %d bytes", len(value))
+	}
+
+	return types.BytesToHash(value), nil
+}
+
+// bytesToNibbles splits each byte into its 2 nibbles, high nibble first
+func bytesToNibbles(b []byte) []byte {
+	nibbles := make([]byte, 0, 2*len(b))
+	for _, c := range b {
+		nibbles = append(nibbles, c>>4, c&0x0f)
+	}
+
+	return nibbles
+}
+
+// decodeCompactKey decodes the hex-prefix encoded key of a short node into its nibbles.
+// The high nibble of the first byte flags a leaf (2) and an odd number of nibbles (1),
+// in which case the low nibble of the first byte is the first key nibble
+func decodeCompactKey(compact []byte) ([]byte, bool) {
+	if len(compact) == 0 {
+		return nil, false
+	}
+
+	flag := compact[0] >> 4
+	nibbles := bytesToNibbles(compact[1:])
+
+	if flag&1 == 1 {
+		nibbles = append([]byte{compact[0] & 0x0f}, nibbles...)
+	}
+
+	return nibbles, flag&2 == 2
+}
@@ -0,0 +1,296 @@
+package staking
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/fastrlp"
+)
+
+// proofLeaf is a storage trie entry, with the nibbles of the hashed slot and the RLP encoded value
+type proofLeaf struct {
+	path  []byte
+	value []byte
+}
+
+// buildStorageProof builds the storage trie of the storage, and returns its root
+// and the proof of the keys, which includes every trie node
+func buildStorageProof(t *testing.T, storage map[types.Hash]types.Hash, keys []types.Hash) (types.Hash, []byte) {
+	t.Helper()
+
+	a := &fastrlp.Arena{}
+
+	leaves := make([]proofLeaf, 0, len(storage))
+
+	for key, value := range storage {
+		if value == types.ZeroHash {
+			continue
+		}
+
+		leaves = append(leaves, proofLeaf{
+			path:  bytesToNibbles(keccak.Keccak256(nil, key.Bytes())),
+			value: a.NewBytes(bytes.TrimLeft(value.Bytes(), "\x00")).MarshalTo(nil),
+		})
+	}
+
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(leaves[i].path, leaves[j].path) < 0
+	})
+
+	nodes := make([][]byte, 0)
+
+	root := types.EmptyRootHash
+
+	if len(leaves) > 0 {
+		encodedRoot := buildTrieNode(a, leaves, 0, &nodes).MarshalTo(nil)
+		nodes = append(nodes, encodedRoot)
+		root = types.BytesToHash(keccak.Keccak256(nil, encodedRoot))
+	}
+
+	keysValue := a.NewArray()
+	for _, key := range keys {
+		keysValue.Set(a.NewCopyBytes(key.Bytes()))
+	}
+
+	nodesValue := a.NewArray()
+	for _, node := range nodes {
+		nodesValue.Set(a.NewCopyBytes(node))
+	}
+
+	proof := a.NewArray()
+	proof.Set(keysValue)
+	proof.Set(nodesValue)
+
+	return root, proof.MarshalTo(nil)
+}
+
+// buildTrieNode builds the trie node of the sorted leaves sharing the first depth nibbles
+func buildTrieNode(a *fastrlp.Arena, leaves []proofLeaf, depth int, nodes *[][]byte) *fastrlp.Value {
+	node := a.NewArray()
+
+	if len(leaves) == 1 {
+		node.Set(a.NewCopyBytes(encodeCompactKey(leaves[0].path[depth:], true)))
+		node.Set(a.NewCopyBytes(leaves[0].value))
+
+		return node
+	}
+
+	// The leaves are sorted, so the common prefix of all the leaves is the one of the first and last leaf
+	first, last := leaves[0].path[depth:], leaves[len(leaves)-1].path[depth:]
+
+	prefixLength := 0
+	for prefixLength < len(first) && first[prefixLength] == last[prefixLength] {
+		prefixLength++
+	}
+
+	if prefixLength > 0 {
+		node.Set(a.NewCopyBytes(encodeCompactKey(first[:prefixLength], false)))
+		node.Set(trieNodeReference(a, buildTrieNode(a, leaves, depth+prefixLength, nodes), nodes))
+
+		return node
+	}
+
+	for nibble := byte(0); nibble < 16; nibble++ {
+		children := make([]proofLeaf, 0)
+
+		for _, leaf := range leaves {
+			if leaf.path[depth] == nibble {
+				children = append(children, leaf)
+			}
+		}
+
+		if len(children) == 0 {
+			node.Set(a.NewNull())
+		} else {
+			node.Set(trieNodeReference(a, buildTrieNode(a, children, depth+1, nodes), nodes))
+		}
+	}
+
+	// The branch value
+	node.Set(a.NewNull())
+
+	return node
+}
+
+// trieNodeReference embeds the nodes shorter than a hash, and references the others by their hash
+func trieNodeReference(a *fastrlp.Arena, node *fastrlp.Value, nodes *[][]byte) *fastrlp.Value {
+	encodedNode := node.MarshalTo(nil)
+	if len(encodedNode) < types.HashLength {
+		return node
+	}
+
+	*nodes = append(*nodes, encodedNode)
+
+	return a.NewCopyBytes(keccak.Keccak256(nil, encodedNode))
+}
+
+// encodeCompactKey hex-prefix encodes the key nibbles
+func encodeCompactKey(nibbles []byte, isLeaf bool) []byte {
+	flag := byte(0)
+	if isLeaf {
+		flag = 2
+	}
+
+	if len(nibbles)%2 == 1 {
+		flag |= 1
+		nibbles = append([]byte{flag}, nibbles...)
+	} else {
+		nibbles = append([]byte{flag, 0}, nibbles...)
+	}
+
+	compact := make([]byte, len(nibbles)/2)
+	for i := range compact {
+		compact[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return compact
+}
+
+func TestPredeployFromStateProof(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 2, MaxValidatorCount: 10}
+
+	source, err := predeployStakingSC(
+		defaultStakingSCBuild,
+		[]stakedValidator{
+			{address: addr1, stake: big.NewInt(3e18)},
+			{address: addr2, stake: big.NewInt(5e18)},
+		},
+		params,
+	)
+	assert.NoError(t, err)
+
+	expected, err := predeployStakingSC(
+		defaultStakingSCBuild,
+		[]stakedValidator{
+			{address: addr1, stake: big.NewInt(3e18)},
+			{address: addr2, stake: big.NewInt(5e18)},
+		},
+		params,
+	)
+	assert.NoError(t, err)
+
+	// The validator index of addr1 is 0, so its slot is proven absent from the trie
+	keys := []types.Hash{
+		SlotKey(validatorsSlot),
+		SlotKey(stakedAmountSlot),
+		SlotKey(minNumValidatorSlot),
+		SlotKey(maxNumValidatorSlot),
+	}
+
+	for indx, validator := range []types.Address{addr1, addr2} {
+		storageIndexes := getStorageIndexes(validator, int64(indx))
+
+		keys = append(
+			keys,
+			types.BytesToHash(storageIndexes.ValidatorsIndex),
+			types.BytesToHash(storageIndexes.AddressToIsValidatorIndex),
+			types.BytesToHash(storageIndexes.AddressToStakedAmountIndex),
+			types.BytesToHash(storageIndexes.AddressToValidatorIndexIndex),
+		)
+	}
+
+	root, proof := buildStorageProof(t, source.Storage, keys)
+
+	storageRoot, err := StorageRoot(source.Storage)
+	assert.NoError(t, err)
+	assert.Equal(t, storageRoot, root)
+
+	t.Run("should rebuild the source validator set from a valid proof", func(t *testing.T) {
+		account, err := PredeployFromStateProof(proof, root, false)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, account)
+	})
+
+	t.Run("should reject a proof of another root", func(t *testing.T) {
+		_, err := PredeployFromStateProof(proof, types.StringToHash("1"), false)
+		assert.ErrorIs(t, err, ErrInvalidStateProof)
+	})
+
+	t.Run("should reject a tampered proof", func(t *testing.T) {
+		// Raise the stake of addr2 in the source storage, and use its nodes with the original root
+		tamperedStorage := make(map[types.Hash]types.Hash, len(source.Storage))
+		for key, value := range source.Storage {
+			tamperedStorage[key] = value
+		}
+
+		tamperedStorage[types.BytesToHash(getAddressMapping(addr2, addressToStakedAmountSlot))] =
+			types.BytesToHash(big.NewInt(6e18).Bytes())
+
+		_, tamperedProof := buildStorageProof(t, tamperedStorage, keys)
+
+		_, err := PredeployFromStateProof(tamperedProof, root, false)
+		assert.ErrorIs(t, err, ErrInvalidStateProof)
+	})
+
+	t.Run("should reject a proof missing a required slot", func(t *testing.T) {
+		_, partialProof := buildStorageProof(t, source.Storage, keys[:len(keys)-1])
+
+		_, err := PredeployFromStateProof(partialProof, root, false)
+		assert.ErrorIs(t, err, ErrIncompleteStateProof)
+	})
+
+	t.Run("should reject malformed proofs", func(t *testing.T) {
+		_, err := PredeployFromStateProof([]byte{0x01}, root, false)
+		assert.ErrorIs(t, err, ErrInvalidStateProof)
+
+		_, err = PredeployFromStateProof(nil, root, false)
+		assert.ErrorIs(t, err, ErrInvalidStateProof)
+	})
+
+	t.Run("should reject the proof of an empty storage", func(t *testing.T) {
+		_, emptyProof := buildStorageProof(t, map[types.Hash]types.Hash{}, keys[:4])
+
+		_, err := PredeployFromStateProof(emptyProof, types.EmptyRootHash, false)
+		assert.ErrorIs(t, err, ErrEmptyValidatorSet)
+
+		// The bounds of an empty storage are zero, which is below the minimum number of validators
+		_, err = PredeployFromStateProof(emptyProof, types.EmptyRootHash, true)
+		assert.ErrorIs(t, err, ErrBoundOutOfLimits)
+	})
+}
+
+func TestPredeployFromStateProofEmptyValidatorSet(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	// A staking SC deployed without validators (ex. when switching from PoA to PoS)
+	source, err := predeployStakingSC(defaultStakingSCBuild, nil, params)
+	assert.NoError(t, err)
+
+	keys := []types.Hash{
+		SlotKey(validatorsSlot),
+		SlotKey(stakedAmountSlot),
+		SlotKey(minNumValidatorSlot),
+		SlotKey(maxNumValidatorSlot),
+	}
+
+	root, proof := buildStorageProof(t, source.Storage, keys)
+
+	t.Run("should reject an empty validator set by default", func(t *testing.T) {
+		_, err := PredeployFromStateProof(proof, root, false)
+		assert.ErrorIs(t, err, ErrEmptyValidatorSet)
+	})
+
+	t.Run("should rebuild an empty validator set when allowed", func(t *testing.T) {
+		account, err := PredeployFromStateProof(proof, root, true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, source, account)
+	})
+}
+
+func TestVerifyStorageProofEmptyRoot(t *testing.T) {
+	_, proof := buildStorageProof(t, map[types.Hash]types.Hash{}, []types.Hash{SlotKey(0)})
+
+	account, proven, err := verifyStorageProof(proof, types.EmptyRootHash)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &chain.GenesisAccount{Storage: map[types.Hash]types.Hash{SlotKey(0): types.ZeroHash}}, account)
+	assert.Contains(t, proven, SlotKey(0))
+}
@@ -0,0 +1,69 @@
+package staking
+
+import (
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// BeaconSlot is the EIP-1967 slot holding the beacon address of a beacon proxy,
+	// bytes32(uint256(keccak256('eip1967.proxy.beacon')) - 1)
+	BeaconSlot = types.StringToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+
+	// Slots of the UpgradeableBeacon state variables
+	beaconOwnerSlot          = int64(0) // Slot 0
+	beaconImplementationSlot = int64(1) // Slot 1
+)
+
+var (
+	// minimalProxyPrefix is the EIP-1167 runtime code preceding the implementation address
+	minimalProxyPrefix = []byte{0x36, 0x3d, 0x3d, 0x37, 0x3d, 0x3d, 0x3d, 0x36, 0x3d, 0x73}
+
+	// minimalProxySuffix is the EIP-1167 runtime code following the implementation address
+	minimalProxySuffix = []byte{
+		0x5a, 0xf4, 0x3d, 0x82, 0x80, 0x3e, 0x90, 0x3d, 0x91, 0x60, 0x2b, 0x57, 0xfd, 0x5b, 0xf3,
+	}
+)
+
+// PredeployMinimalProxy generates the genesis account of an EIP-1167 minimal proxy (clone),
+// which delegates all calls to the implementation SC at implAddr.
+// The clone has no storage of its own set at genesis
+//
+// More information:
+// https://eips.ethereum.org/EIPS/eip-1167
+func PredeployMinimalProxy(implAddr types.Address) *chain.GenesisAccount {
+	code := make([]byte, 0, len(minimalProxyPrefix)+types.AddressLength+len(minimalProxySuffix))
+	code = append(code, minimalProxyPrefix...)
+	code = append(code, implAddr.Bytes()...)
+	code = append(code, minimalProxySuffix...)
+
+	return &chain.GenesisAccount{
+		Code: code,
+	}
+}
+
+// PredeployBeaconProxy generates the genesis account storage of an EIP-1967 beacon proxy,
+// which reads the implementation address from the beacon at beaconAddr.
+// The proxy code is not embedded, so the caller sets the account code to the compiled BeaconProxy
+//
+// More information:
+// https://eips.ethereum.org/EIPS/eip-1967
+func PredeployBeaconProxy(beaconAddr types.Address) *chain.GenesisAccount {
+	return &chain.GenesisAccount{
+		Storage: map[types.Hash]types.Hash{
+			BeaconSlot: types.BytesToHash(beaconAddr.Bytes()),
+		},
+	}
+}
+
+// PredeployBeacon generates the genesis account storage of an UpgradeableBeacon,
+// which points the beacon proxies at the implementation SC at implAddr and can be upgraded by owner.
+// The beacon code is not embedded, so the caller sets the account code to the compiled UpgradeableBeacon
+func PredeployBeacon(implAddr, owner types.Address) *chain.GenesisAccount {
+	return &chain.GenesisAccount{
+		Storage: map[types.Hash]types.Hash{
+			SlotKey(beaconOwnerSlot):          types.BytesToHash(owner.Bytes()),
+			SlotKey(beaconImplementationSlot): types.BytesToHash(implAddr.Bytes()),
+		},
+	}
+}
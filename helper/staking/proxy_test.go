@@ -0,0 +1,50 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployMinimalProxy(t *testing.T) {
+	implAddr := types.StringToAddress("0xbebebebebebebebebebebebebebebebebebebebe")
+
+	account := PredeployMinimalProxy(implAddr)
+
+	assert.Equal(
+		t,
+		hex.MustDecodeHex(
+			"0x363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3",
+		),
+		account.Code,
+	)
+	assert.Len(t, account.Code, 45)
+	assert.Empty(t, account.Storage)
+}
+
+func TestPredeployBeaconProxy(t *testing.T) {
+	implAddr := types.StringToAddress("0x1001")
+	beaconAddr := types.StringToAddress("0x1002")
+	owner := types.StringToAddress("0x1003")
+
+	beacon := PredeployBeacon(implAddr, owner)
+	proxy := PredeployBeaconProxy(beaconAddr)
+
+	// The proxy points at the beacon, which points at the implementation
+	assert.Equal(t, map[types.Hash]types.Hash{
+		BeaconSlot: types.BytesToHash(beaconAddr.Bytes()),
+	}, proxy.Storage)
+
+	assert.Equal(t, map[types.Hash]types.Hash{
+		SlotKey(0): types.BytesToHash(owner.Bytes()),
+		SlotKey(1): types.BytesToHash(implAddr.Bytes()),
+	}, beacon.Storage)
+
+	// bytes32(uint256(keccak256('eip1967.proxy.beacon')) - 1)
+	beaconSlot := new(big.Int).SetBytes(keccak.Keccak256(nil, []byte("eip1967.proxy.beacon")))
+	assert.Equal(t, types.BytesToHash(beaconSlot.Sub(beaconSlot, big.NewInt(1)).Bytes()), BeaconSlot)
+}
@@ -0,0 +1,107 @@
+package staking
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrNegativeBalance     = errors.New("account balance is negative")
+	ErrStorageRootMismatch = errors.New("storage root doesn't match the expected root")
+)
+
+var storageRootArenaPool fastrlp.ArenaPool
+
+// StorageRoot returns the root hash of the storage trie built from the passed in account storage,
+// the same way the state commits it: keys are hashed, values are RLP encoded
+// without the leading zeroes, and zero values are left out of the trie
+func StorageRoot(storage map[types.Hash]types.Hash) (types.Hash, error) {
+	txn := itrie.NewTrie().Txn()
+
+	arena := storageRootArenaPool.Get()
+	defer storageRootArenaPool.Put(arena)
+
+	for key, value := range storage {
+		if value == types.ZeroHash {
+			continue
+		}
+
+		txn.Insert(
+			keccak.Keccak256(nil, key.Bytes()),
+			arena.NewBytes(bytes.TrimLeft(value.Bytes(), "\x00")).MarshalTo(nil),
+		)
+		arena.Reset()
+	}
+
+	root, err := txn.Hash()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(root), nil
+}
+
+// AssertStorageRoot checks that the StorageRoot of the account storage is the expected root,
+// so operators can pin the genesis storage root in their config and detect any drift
+func AssertStorageRoot(account *chain.GenesisAccount, expected types.Hash) error {
+	root, err := StorageRoot(account.Storage)
+	if err != nil {
+		return err
+	}
+
+	if root != expected {
+		return fmt.Errorf("%w, expected %s, got %s", ErrStorageRootMismatch, expected, root)
+	}
+
+	return nil
+}
+
+// ToStateObject converts the genesis account into a state object that can be committed directly
+// to the state trie. The storage entries are applied on top of an empty storage trie on commit,
+// so the committed account storage root is the StorageRoot of the account storage
+func ToStateObject(addr types.Address, account *chain.GenesisAccount) (*state.Object, error) {
+	balance := big.NewInt(0)
+
+	if account.Balance != nil {
+		if account.Balance.Sign() < 0 {
+			return nil, fmt.Errorf("%w, balance %s", ErrNegativeBalance, account.Balance)
+		}
+
+		balance.Set(account.Balance)
+	}
+
+	obj := &state.Object{
+		Address:   addr,
+		CodeHash:  types.BytesToHash(keccak.Keccak256(nil, account.Code)),
+		Balance:   balance,
+		Root:      types.EmptyRootHash,
+		Nonce:     account.Nonce,
+		DirtyCode: len(account.Code) != 0,
+		Code:      account.Code,
+		Storage:   make([]*state.StorageObject, 0, len(account.Storage)),
+	}
+
+	for _, key := range sortedStorageKeys(account.Storage) {
+		value := account.Storage[key]
+		if value == types.ZeroHash {
+			// Zero values are not stored in the trie
+			continue
+		}
+
+		obj.Storage = append(obj.Storage, &state.StorageObject{
+			Key: key.Bytes(),
+			Val: value.Bytes(),
+		})
+	}
+
+	return obj, nil
+}
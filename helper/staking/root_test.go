@@ -0,0 +1,120 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageRoot(t *testing.T) {
+	t.Run("should return the empty root for empty storage", func(t *testing.T) {
+		root, err := StorageRoot(map[types.Hash]types.Hash{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, types.EmptyRootHash, root)
+	})
+
+	t.Run("should be deterministic", func(t *testing.T) {
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		// Copy the storage into a new map, so the iteration order differs
+		storageCopy := make(map[types.Hash]types.Hash, len(account.Storage))
+		for key, value := range account.Storage {
+			storageCopy[key] = value
+		}
+
+		root, err := StorageRoot(account.Storage)
+		assert.NoError(t, err)
+
+		for i := 0; i < 10; i++ {
+			copyRoot, err := StorageRoot(storageCopy)
+			assert.NoError(t, err)
+
+			assert.Equal(t, root, copyRoot)
+		}
+
+		assert.NotEqual(t, types.EmptyRootHash, root)
+	})
+
+	t.Run("should depend on the storage values", func(t *testing.T) {
+		root1, err := StorageRoot(map[types.Hash]types.Hash{SlotKey(0): types.StringToHash("1")})
+		assert.NoError(t, err)
+
+		root2, err := StorageRoot(map[types.Hash]types.Hash{SlotKey(0): types.StringToHash("2")})
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, root1, root2)
+	})
+
+	t.Run("should ignore zero values", func(t *testing.T) {
+		root, err := StorageRoot(map[types.Hash]types.Hash{SlotKey(0): types.ZeroHash})
+		assert.NoError(t, err)
+
+		assert.Equal(t, types.EmptyRootHash, root)
+	})
+}
+
+func TestAssertStorageRoot(t *testing.T) {
+	// Storage root of the staking SC predeployed with addr1 and addr2, and validator bounds [1, 10]
+	pinnedRoot := types.StringToHash("0x2f370ceae6c8ae500e1b614eb97f0a438b2800f96fb40965a6d76a10761ba3cf")
+
+	account, err := PredeployStakingSC(
+		[]types.Address{addr1, addr2},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	assert.NoError(t, AssertStorageRoot(account, pinnedRoot))
+
+	// Changing a single slot changes the root
+	account.Storage[SlotKey(maxNumValidatorSlot)] = types.BytesToHash(big.NewInt(11).Bytes())
+
+	assert.ErrorIs(t, AssertStorageRoot(account, pinnedRoot), ErrStorageRootMismatch)
+}
+
+func TestToStateObject(t *testing.T) {
+	t.Run("should commit the account with its storage root", func(t *testing.T) {
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		obj, err := ToStateObject(stakingContracts.AddrStakingContract, account)
+		assert.NoError(t, err)
+
+		expectedRoot, err := StorageRoot(account.Storage)
+		assert.NoError(t, err)
+
+		stateStorage := itrie.NewState(itrie.NewMemoryStorage())
+		snapshot, _ := stateStorage.NewSnapshot().Commit([]*state.Object{obj})
+
+		txn := state.NewTxn(stateStorage, snapshot)
+
+		committed, ok := txn.GetAccount(stakingContracts.AddrStakingContract)
+		assert.True(t, ok)
+
+		assert.Equal(t, expectedRoot, committed.Root)
+		assert.Equal(t, account.Balance, committed.Balance)
+		assert.Equal(t, account.Code, txn.GetCode(stakingContracts.AddrStakingContract))
+
+		for key, value := range account.Storage {
+			assert.Equal(t, value, txn.GetState(stakingContracts.AddrStakingContract, key))
+		}
+	})
+
+	t.Run("should reject a negative balance", func(t *testing.T) {
+		_, err := ToStateObject(addr1, &chain.GenesisAccount{Balance: big.NewInt(-1)})
+		assert.ErrorIs(t, err, ErrNegativeBalance)
+	})
+}
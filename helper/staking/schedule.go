@@ -0,0 +1,87 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrScheduledSetsNotSupported  = errors.New("staking SC doesn't support scheduled validator sets")
+	ErrScheduledSetsNotIncreasing = errors.New("scheduled validator set epochs are not increasing")
+)
+
+// ScheduledSet is a validator set that takes over at the start of the given epoch
+type ScheduledSet struct {
+	Epoch      uint64
+	Validators []types.Address
+}
+
+// PredeployStakingSCWithSchedule is a helper method for setting up the staking smart contract account
+// of the given build, using the passed in validators as pre-staked validators.
+// Each of the scheduled sets, which must be ordered by strictly increasing epochs, is written to the
+// ScheduledSets mapping as the address[] stored under its epoch, so the validator rotations are fixed
+// at genesis. The build must support scheduled rotations (ex. a build registered with RegisterStakingSCBuild)
+func PredeployStakingSCWithSchedule(
+	build StakingSCBuild,
+	validators []types.Address,
+	schedule []ScheduledSet,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	if build.Slots.ScheduledSets == nil {
+		return nil, ErrScheduledSetsNotSupported
+	}
+
+	for indx, set := range schedule {
+		if indx > 0 && set.Epoch <= schedule[indx-1].Epoch {
+			return nil, fmt.Errorf(
+				"%w, epoch %d follows epoch %d",
+				ErrScheduledSetsNotIncreasing,
+				set.Epoch,
+				schedule[indx-1].Epoch,
+			)
+		}
+
+		// A scheduled set below the minimum would halt the chain at the rotation
+		if uint64(len(set.Validators)) < params.MinValidatorCount {
+			return nil, fmt.Errorf(
+				"%w, epoch %d has %d validators, minimum is %d",
+				ErrTooFewValidators,
+				set.Epoch,
+				len(set.Validators),
+				params.MinValidatorCount,
+			)
+		}
+	}
+
+	stakedValidators, err := defaultStakedValidators(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	stakingAccount, err := predeployStakingSC(build, stakedValidators, params)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledSetsSlot := *build.Slots.ScheduledSets
+
+	for _, set := range schedule {
+		// The array of the epoch is located at keccak(epoch . slot), with the size stored there
+		// and the elements from keccak(keccak(epoch . slot)) on
+		arraySlot := getWordMapping(types.BytesToHash(new(big.Int).SetUint64(set.Epoch).Bytes()), scheduledSetsSlot)
+
+		stakingAccount.Storage[types.BytesToHash(arraySlot)] =
+			types.BytesToHash(big.NewInt(int64(len(set.Validators))).Bytes())
+
+		for indx, validator := range set.Validators {
+			stakingAccount.Storage[types.BytesToHash(getIndexWithOffset(Hasher(arraySlot), int64(indx)))] =
+				types.BytesToHash(validator.Bytes())
+		}
+	}
+
+	return stakingAccount, nil
+}
@@ -0,0 +1,97 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployStakingSCWithSchedule(t *testing.T) {
+	scheduledSetsSlot := int64(7)
+
+	build := defaultStakingSCBuild
+	build.Slots.ScheduledSets = &scheduledSetsSlot
+
+	addr3 := types.StringToAddress("3")
+
+	validators := []types.Address{addr1, addr2}
+
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	t.Run("should write each scheduled set to its own slot", func(t *testing.T) {
+		schedule := []ScheduledSet{
+			{Epoch: 10, Validators: []types.Address{addr2, addr3}},
+			{Epoch: 20, Validators: []types.Address{addr3}},
+		}
+
+		account, err := PredeployStakingSCWithSchedule(build, validators, schedule, params)
+		assert.NoError(t, err)
+
+		arraySlots := make(map[types.Hash]struct{}, len(schedule))
+
+		for _, set := range schedule {
+			arraySlot := getWordMapping(types.BytesToHash(big.NewInt(int64(set.Epoch)).Bytes()), scheduledSetsSlot)
+			arraySlots[types.BytesToHash(arraySlot)] = struct{}{}
+
+			assert.Equal(
+				t,
+				types.BytesToHash(big.NewInt(int64(len(set.Validators))).Bytes()),
+				account.Storage[types.BytesToHash(arraySlot)],
+			)
+
+			for indx, validator := range set.Validators {
+				assert.Equal(
+					t,
+					types.BytesToHash(validator.Bytes()),
+					account.Storage[types.BytesToHash(getIndexWithOffset(Hasher(arraySlot), int64(indx)))],
+				)
+			}
+		}
+
+		assert.Len(t, arraySlots, len(schedule))
+
+		// The genesis validator set is unchanged
+		genesisValidators, err := DecodeValidators(account)
+		assert.NoError(t, err)
+		assert.Equal(t, validators, genesisValidators)
+	})
+
+	t.Run("should reject epochs that are not increasing", func(t *testing.T) {
+		_, err := PredeployStakingSCWithSchedule(
+			build,
+			validators,
+			[]ScheduledSet{
+				{Epoch: 20, Validators: []types.Address{addr1}},
+				{Epoch: 20, Validators: []types.Address{addr2}},
+			},
+			params,
+		)
+		assert.ErrorIs(t, err, ErrScheduledSetsNotIncreasing)
+	})
+
+	t.Run("should reject a set below the minimum", func(t *testing.T) {
+		_, err := PredeployStakingSCWithSchedule(
+			build,
+			validators,
+			[]ScheduledSet{{Epoch: 10}},
+			params,
+		)
+		assert.ErrorIs(t, err, ErrTooFewValidators)
+	})
+
+	t.Run("should reject a scheduled sets slot over a core slot", func(t *testing.T) {
+		collidingBuild := defaultStakingSCBuild
+		collidingSlot := validatorsSlot
+		collidingBuild.Slots.ScheduledSets = &collidingSlot
+
+		_, err := PredeployStakingSCWithSchedule(collidingBuild, validators, nil, params)
+		assert.ErrorIs(t, err, ErrSlotCollision)
+	})
+
+	t.Run("should fail for the embedded staking SC", func(t *testing.T) {
+		_, err := PredeployStakingSCWithSchedule(defaultStakingSCBuild, validators, nil, params)
+		assert.ErrorIs(t, err, ErrScheduledSetsNotSupported)
+	})
+}
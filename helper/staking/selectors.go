@@ -0,0 +1,85 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/umbracle/ethgo/abi"
+)
+
+var ErrABIBytecodeMismatch = errors.New("ABI method selectors don't match the bytecode dispatcher")
+
+const (
+	// selectorLength is the length of a function selector
+	selectorLength = 4
+
+	// push4 is the opcode pushing a 4 byte value
+	push4 = evm.PUSH1 + selectorLength - 1
+)
+
+// AssertABIBytecodeConsistency checks, on a best effort basis, that the ABI and the runtime code
+// come from the same compilation, by comparing the ABI method selectors with the selectors
+// the code dispatches on. The dispatcher selectors are the PUSH4 values compared with EQ,
+// which is how solc matches the selector of the call
+func AssertABIBytecodeConsistency(contractABI *abi.ABI, code []byte) error {
+	abiSelectors := make(map[string]bool, len(contractABI.Methods))
+	for _, method := range contractABI.Methods {
+		abiSelectors[hex.EncodeToHex(method.ID())] = true
+	}
+
+	codeSelectors := dispatcherSelectors(code)
+
+	onlyInABI := selectorSetDifference(abiSelectors, codeSelectors)
+	onlyInCode := selectorSetDifference(codeSelectors, abiSelectors)
+
+	if len(onlyInABI) != 0 || len(onlyInCode) != 0 {
+		return fmt.Errorf(
+			"%w, only in the ABI: %v, only in the bytecode: %v",
+			ErrABIBytecodeMismatch,
+			onlyInABI,
+			onlyInCode,
+		)
+	}
+
+	return nil
+}
+
+// dispatcherSelectors returns the selectors the code compares the call selector with
+func dispatcherSelectors(code []byte) map[string]bool {
+	code = codeWithoutMetadata(code)
+	selectors := make(map[string]bool)
+
+	for pc := 0; pc < len(code); pc++ {
+		op := code[pc]
+
+		// PUSH4 selector, EQ
+		if op == push4 && pc+selectorLength+1 < len(code) && code[pc+selectorLength+1] == evm.EQ {
+			selectors[hex.EncodeToHex(code[pc+1:pc+1+selectorLength])] = true
+		}
+
+		// Skip the push data
+		if op >= evm.PUSH1 && op <= evm.PUSH32 {
+			pc += int(op - evm.PUSH1 + 1)
+		}
+	}
+
+	return selectors
+}
+
+// selectorSetDifference returns the selectors of a that are not in b, in ascending order
+func selectorSetDifference(a, b map[string]bool) []string {
+	diff := make([]string, 0)
+
+	for selector := range a {
+		if !b[selector] {
+			diff = append(diff, selector)
+		}
+	}
+
+	sort.Strings(diff)
+
+	return diff
+}
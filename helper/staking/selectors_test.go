@@ -0,0 +1,51 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo/abi"
+)
+
+func TestAssertABIBytecodeConsistency(t *testing.T) {
+	// PUSH1 0x00 CALLDATALOAD PUSH1 0xe0 SHR
+	// DUP1 PUSH4 validators() EQ PUSH2 0x0020 JUMPI
+	// DUP1 PUSH4 stake() EQ PUSH2 0x0030 JUMPI
+	// STOP
+	code := hex.MustDecodeHex(
+		"0x60003560e01c" +
+			"8063ca1e78191461002057" +
+			"80633a4b66f11461003057" +
+			"00",
+	)
+
+	t.Run("should accept the ABI of the dispatched methods", func(t *testing.T) {
+		contractABI := abi.MustNewABI(`[
+			{"type": "function", "name": "validators", "inputs": [], "outputs": [{"type": "address[]"}]},
+			{"type": "function", "name": "stake", "inputs": [], "outputs": []}
+		]`)
+
+		assert.NoError(t, AssertABIBytecodeConsistency(contractABI, code))
+	})
+
+	t.Run("should reject the ABI of another contract", func(t *testing.T) {
+		contractABI := abi.MustNewABI(`[
+			{"type": "function", "name": "validators", "inputs": [], "outputs": [{"type": "address[]"}]},
+			{"type": "function", "name": "unstake", "inputs": [], "outputs": []}
+		]`)
+
+		err := AssertABIBytecodeConsistency(contractABI, code)
+		assert.ErrorIs(t, err, ErrABIBytecodeMismatch)
+
+		// unstake() is only in the ABI, and stake() only in the bytecode
+		assert.Contains(t, err.Error(), "only in the ABI: [0x2def6620]")
+		assert.Contains(t, err.Error(), "only in the bytecode: [0x3a4b66f1]")
+	})
+}
+
+func TestAssertABIBytecodeConsistencyEmbeddedStakingSC(t *testing.T) {
+	// The staking SC ABI must describe the embedded bytecode, including the public state variable getters
+	assert.NoError(t, AssertABIBytecodeConsistency(abis.StakingABI, hex.MustDecodeHex(StakingSCBytecode)))
+}
@@ -0,0 +1,118 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// DefaultSolcVersion is the solc version used to build the embedded staking SC
+	DefaultSolcVersion = "0.8.7"
+)
+
+var (
+	ErrUnknownSolcVersion    = errors.New("unknown staking SC solc version")
+	ErrSolcVersionRegistered = errors.New("staking SC solc version already registered")
+)
+
+// StakingSCBuild is the staking SC built with a specific solc version.
+// Different solc versions emit different runtime bytecode, and can
+// lay out the storage of the same source differently
+type StakingSCBuild struct {
+	Bytecode string       // hex encoded runtime bytecode
+	Slots    StorageSlots // storage slots of the SC state variables
+}
+
+// defaultStakingSCBuild is the embedded staking SC build
+// Code retrieved from https://github.com/0xPolygon/staking-contracts
+var defaultStakingSCBuild = StakingSCBuild{
+	Bytecode: StakingSCBytecode,
+	Slots:    DefaultStorageSlots,
+}
+
+var (
+	stakingSCBuilds = map[string]StakingSCBuild{
+		DefaultSolcVersion: defaultStakingSCBuild,
+	}
+	stakingSCBuildsLock sync.RWMutex
+)
+
+// RegisterStakingSCBuild registers the staking SC build for the given solc version,
+// so it can be predeployed using PredeployStakingSCForSolc
+func RegisterStakingSCBuild(version string, build StakingSCBuild) error {
+	if _, err := hex.DecodeHex(build.Bytecode); err != nil {
+		return fmt.Errorf("unable to decode staking SC bytecode for solc %s, %w", version, err)
+	}
+
+	stakingSCBuildsLock.Lock()
+	defer stakingSCBuildsLock.Unlock()
+
+	if _, ok := stakingSCBuilds[version]; ok {
+		return fmt.Errorf("%w: %s", ErrSolcVersionRegistered, version)
+	}
+
+	stakingSCBuilds[version] = build
+
+	return nil
+}
+
+// SupportedSolcVersions returns the sorted solc versions with a registered staking SC build
+func SupportedSolcVersions() []string {
+	stakingSCBuildsLock.RLock()
+	defer stakingSCBuildsLock.RUnlock()
+
+	versions := make([]string, 0, len(stakingSCBuilds))
+	for version := range stakingSCBuilds {
+		versions = append(versions, version)
+	}
+
+	sort.Strings(versions)
+
+	return versions
+}
+
+// StakingSCBuildForSolc returns the staking SC build registered for the given solc version,
+// so the predeploy helpers of the optional staking SC features can be used with it
+func StakingSCBuildForSolc(version string) (StakingSCBuild, error) {
+	stakingSCBuildsLock.RLock()
+	build, ok := stakingSCBuilds[version]
+	stakingSCBuildsLock.RUnlock()
+
+	if !ok {
+		return StakingSCBuild{}, fmt.Errorf(
+			"%w: %s, supported versions: %s",
+			ErrUnknownSolcVersion,
+			version,
+			strings.Join(SupportedSolcVersions(), ", "),
+		)
+	}
+
+	return build, nil
+}
+
+// PredeployStakingSCForSolc is a helper method for setting up the staking smart contract account
+// built with the given solc version, using the passed in validators as pre-staked validators
+func PredeployStakingSCForSolc(
+	version string,
+	validators []types.Address,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	build, err := StakingSCBuildForSolc(version)
+	if err != nil {
+		return nil, err
+	}
+
+	stakedValidators, err := defaultStakedValidators(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	return predeployStakingSC(build, stakedValidators, params)
+}
@@ -0,0 +1,230 @@
+package staking
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrUnknownLayoutType  = errors.New("unknown type in the solc storage layout")
+	ErrInvalidLayoutEntry = errors.New("invalid offset or size in the solc storage layout")
+)
+
+// Storage encodings of the solc storage layout types
+const (
+	encodingInplace      = "inplace"
+	encodingMapping      = "mapping"
+	encodingDynamicArray = "dynamic_array"
+)
+
+// SolcStorageLayout is the storage layout of a contract, as output by solc
+// with the storageLayout output selection.
+//
+// More information:
+// https://docs.soliditylang.org/en/latest/internals/layout_in_storage.html#json-output
+type SolcStorageLayout struct {
+	Storage []SolcStorageEntry         `json:"storage"`
+	Types   map[string]SolcStorageType `json:"types"`
+}
+
+// SolcStorageEntry is a state variable in the solc storage layout
+type SolcStorageEntry struct {
+	Label  string `json:"label"`
+	Offset int    `json:"offset"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+// SolcStorageType is a type in the solc storage layout
+type SolcStorageType struct {
+	Encoding      string `json:"encoding"`
+	Label         string `json:"label"`
+	NumberOfBytes string `json:"numberOfBytes"`
+	Key           string `json:"key,omitempty"`
+	Value         string `json:"value,omitempty"`
+	Base          string `json:"base,omitempty"`
+}
+
+// ParseSolcStorageLayout parses the solc storage layout JSON
+func ParseSolcStorageLayout(layoutJSON []byte) (*SolcStorageLayout, error) {
+	layout := &SolcStorageLayout{}
+	if err := json.Unmarshal(layoutJSON, layout); err != nil {
+		return nil, fmt.Errorf("unable to parse solc storage layout, %w", err)
+	}
+
+	return layout, nil
+}
+
+// DecodeWithLayout maps every slot of the account storage to its label and decoded value,
+// using the solc storage layout of the contract. Mappings keyed by address are decoded for
+// the passed in validators, and storage slots not covered by the layout are labeled as unknown.
+// Variables and array elements packed in a shared slot are decoded from their own bytes of the slot
+func DecodeWithLayout(
+	account *chain.GenesisAccount,
+	layout *SolcStorageLayout,
+	validators []types.Address,
+) (map[string]string, error) {
+	decoded := make(map[string]string)
+	covered := make(map[types.Hash]struct{})
+
+	// read decodes the value at offset bytes from the lower-order end of the word at the storage index,
+	// and marks the index as covered
+	read := func(label string, index []byte, offset int, typ SolcStorageType) error {
+		size, err := typ.packedSize()
+		if err != nil {
+			return err
+		}
+
+		if offset < 0 || offset+size > types.HashLength {
+			return fmt.Errorf("%w, %s has offset %d and %d bytes", ErrInvalidLayoutEntry, label, offset, size)
+		}
+
+		key := types.BytesToHash(index)
+		covered[key] = struct{}{}
+
+		if value, ok := account.Storage[key]; ok {
+			decoded[label] = decodeLayoutValue(
+				types.BytesToHash(value[types.HashLength-offset-size:types.HashLength-offset]),
+				typ,
+			)
+		}
+
+		return nil
+	}
+
+	for _, entry := range layout.Storage {
+		slot, ok := new(big.Int).SetString(entry.Slot, 10)
+		if !ok || !slot.IsInt64() {
+			return nil, fmt.Errorf("invalid slot %q of %s", entry.Slot, entry.Label)
+		}
+
+		typ, err := layout.lookupType(entry.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		switch typ.Encoding {
+		case encodingInplace:
+			if err := read(entry.Label, slot.Bytes(), entry.Offset, typ); err != nil {
+				return nil, err
+			}
+		case encodingDynamicArray:
+			elementType, err := layout.lookupType(typ.Base)
+			if err != nil {
+				return nil, err
+			}
+
+			elementSize, err := elementType.packedSize()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := read(entry.Label+".length", slot.Bytes(), 0, SolcStorageType{Label: "uint256"}); err != nil {
+				return nil, err
+			}
+
+			// Each populated element takes a storage entry, so a longer array can't be in the storage.
+			// Checking the length before iterating prevents looping over a corrupted length
+			length := new(big.Int).SetBytes(account.Storage[types.BytesToHash(slot.Bytes())].Bytes())
+			if !length.IsInt64() || length.Int64() > int64(len(account.Storage)) {
+				return nil, fmt.Errorf(
+					"%w: %s, %s has %d storage entries",
+					ErrInvalidValidatorsArraySize,
+					length,
+					entry.Label,
+					len(account.Storage),
+				)
+			}
+
+			// Elements that fit together in a word share a slot, starting from its lower-order end
+			elementsPerSlot := int64(types.HashLength / elementSize)
+
+			for indx := int64(0); indx < length.Int64(); indx++ {
+				if err := read(
+					fmt.Sprintf("%s[%d]", entry.Label, indx),
+					getArrayElementIndex(slot.Int64(), indx/elementsPerSlot),
+					int(indx%elementsPerSlot)*elementSize,
+					elementType,
+				); err != nil {
+					return nil, err
+				}
+			}
+		case encodingMapping:
+			valueType, err := layout.lookupType(typ.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, validator := range validators {
+				if err := read(
+					fmt.Sprintf("%s[%s]", entry.Label, validator),
+					getAddressMapping(validator, slot.Int64()),
+					0,
+					valueType,
+				); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s encoding %s", ErrUnknownLayoutType, entry.Type, typ.Encoding)
+		}
+	}
+
+	for key, value := range account.Storage {
+		if _, ok := covered[key]; !ok {
+			decoded[fmt.Sprintf("%s[%s]", unknownStorageLabel, key)] = value.String()
+		}
+	}
+
+	return decoded, nil
+}
+
+// packedSize returns the number of bytes the type takes in a storage word.
+// Types of a word or more (ex. structs) start a slot, and are decoded from their first word
+func (t SolcStorageType) packedSize() (int, error) {
+	if t.NumberOfBytes == "" {
+		return types.HashLength, nil
+	}
+
+	size, err := strconv.Atoi(t.NumberOfBytes)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("%w, %s has %q bytes", ErrInvalidLayoutEntry, t.Label, t.NumberOfBytes)
+	}
+
+	if size > types.HashLength {
+		return types.HashLength, nil
+	}
+
+	return size, nil
+}
+
+// lookupType returns the type with the given identifier from the layout
+func (l *SolcStorageLayout) lookupType(id string) (SolcStorageType, error) {
+	typ, ok := l.Types[id]
+	if !ok {
+		return SolcStorageType{}, fmt.Errorf("%w: %s", ErrUnknownLayoutType, id)
+	}
+
+	return typ, nil
+}
+
+// decodeLayoutValue decodes the storage word according to the type label
+func decodeLayoutValue(value types.Hash, typ SolcStorageType) string {
+	switch {
+	case typ.Label == "bool":
+		return fmt.Sprintf("%t", value != types.ZeroHash)
+	case typ.Label == "address" || strings.HasPrefix(typ.Label, "address "):
+		return types.BytesToAddress(value.Bytes()).String()
+	case strings.HasPrefix(typ.Label, "uint"):
+		return new(big.Int).SetBytes(value.Bytes()).String()
+	default:
+		return value.String()
+	}
+}
@@ -0,0 +1,146 @@
+package staking
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// stakingStorageLayout is the solc storage layout of the embedded staking SC
+const stakingStorageLayout = `{
+	"storage": [
+		{"label": "_validators", "offset": 0, "slot": "0", "type": "t_array(t_address)dyn_storage"},
+		{"label": "_addressToIsValidator", "offset": 0, "slot": "1", "type": "t_mapping(t_address,t_bool)"},
+		{"label": "_addressToStakedAmount", "offset": 0, "slot": "2", "type": "t_mapping(t_address,t_uint256)"},
+		{"label": "_addressToValidatorIndex", "offset": 0, "slot": "3", "type": "t_mapping(t_address,t_uint256)"},
+		{"label": "_stakedAmount", "offset": 0, "slot": "4", "type": "t_uint256"},
+		{"label": "_minimumNumValidators", "offset": 0, "slot": "5", "type": "t_uint256"},
+		{"label": "_maximumNumValidators", "offset": 0, "slot": "6", "type": "t_uint256"}
+	],
+	"types": {
+		"t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+		"t_array(t_address)dyn_storage": {
+			"base": "t_address", "encoding": "dynamic_array", "label": "address[]", "numberOfBytes": "32"
+		},
+		"t_bool": {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+		"t_mapping(t_address,t_bool)": {
+			"encoding": "mapping", "key": "t_address", "label": "mapping(address => bool)",
+			"numberOfBytes": "32", "value": "t_bool"
+		},
+		"t_mapping(t_address,t_uint256)": {
+			"encoding": "mapping", "key": "t_address", "label": "mapping(address => uint256)",
+			"numberOfBytes": "32", "value": "t_uint256"
+		},
+		"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"}
+	}
+}`
+
+func TestDecodeWithLayout(t *testing.T) {
+	validators := []types.Address{addr1, addr2}
+
+	layout, err := ParseSolcStorageLayout([]byte(stakingStorageLayout))
+	assert.NoError(t, err)
+
+	account, err := PredeployStakingSC(validators, PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10})
+	assert.NoError(t, err)
+
+	extraKey := types.StringToHash("0xabcd")
+	account.Storage[extraKey] = types.StringToHash("0x1")
+
+	decoded, err := DecodeWithLayout(account, layout, validators)
+	assert.NoError(t, err)
+
+	assert.Equal(
+		t,
+		map[string]string{
+			"_validators.length": "2",
+			"_validators[0]":     addr1.String(),
+			"_validators[1]":     addr2.String(),
+			fmt.Sprintf("_addressToIsValidator[%s]", addr1):    "true",
+			fmt.Sprintf("_addressToIsValidator[%s]", addr2):    "true",
+			fmt.Sprintf("_addressToStakedAmount[%s]", addr1):   "10000000000000000000",
+			fmt.Sprintf("_addressToStakedAmount[%s]", addr2):   "10000000000000000000",
+			fmt.Sprintf("_addressToValidatorIndex[%s]", addr1): "0",
+			fmt.Sprintf("_addressToValidatorIndex[%s]", addr2): "1",
+			"_stakedAmount":                      "20000000000000000000",
+			"_minimumNumValidators":              "1",
+			"_maximumNumValidators":              "10",
+			fmt.Sprintf("unknown[%s]", extraKey): types.StringToHash("0x1").String(),
+		},
+		decoded,
+	)
+
+	delete(layout.Types, "t_uint256")
+
+	_, err = DecodeWithLayout(account, layout, validators)
+	assert.ErrorIs(t, err, ErrUnknownLayoutType)
+}
+
+func TestDecodeWithLayoutPackedEntries(t *testing.T) {
+	// bool _paused and uint64 _epoch packed in slot 0, and uint8[] _weights at slot 1
+	layout, err := ParseSolcStorageLayout([]byte(`{
+		"storage": [
+			{"label": "_paused", "offset": 0, "slot": "0", "type": "t_bool"},
+			{"label": "_epoch", "offset": 1, "slot": "0", "type": "t_uint64"},
+			{"label": "_weights", "offset": 0, "slot": "1", "type": "t_array(t_uint8)dyn_storage"}
+		],
+		"types": {
+			"t_bool": {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+			"t_uint64": {"encoding": "inplace", "label": "uint64", "numberOfBytes": "8"},
+			"t_uint8": {"encoding": "inplace", "label": "uint8", "numberOfBytes": "1"},
+			"t_array(t_uint8)dyn_storage": {
+				"base": "t_uint8", "encoding": "dynamic_array", "label": "uint8[]", "numberOfBytes": "32"
+			}
+		}
+	}`))
+	assert.NoError(t, err)
+
+	account := &chain.GenesisAccount{
+		Storage: map[types.Hash]types.Hash{
+			SlotKey(0): types.StringToHash("0x2a01"),
+			SlotKey(1): types.StringToHash("0x3"),
+			// The 3 elements share the first element slot, from its lower-order end
+			types.BytesToHash(getArrayElementIndex(1, 0)): types.StringToHash("0x030201"),
+		},
+	}
+
+	t.Run("should decode the packed values from their own bytes", func(t *testing.T) {
+		decoded, err := DecodeWithLayout(account, layout, nil)
+		assert.NoError(t, err)
+
+		assert.Equal(
+			t,
+			map[string]string{
+				"_paused":         "true",
+				"_epoch":          "42",
+				"_weights.length": "3",
+				"_weights[0]":     "1",
+				"_weights[1]":     "2",
+				"_weights[2]":     "3",
+			},
+			decoded,
+		)
+	})
+
+	t.Run("should reject an array length over the storage entries", func(t *testing.T) {
+		oversized := &chain.GenesisAccount{
+			Storage: map[types.Hash]types.Hash{
+				SlotKey(1): types.BytesToHash(big.NewInt(1 << 52).Bytes()),
+			},
+		}
+
+		_, err := DecodeWithLayout(oversized, layout, nil)
+		assert.ErrorIs(t, err, ErrInvalidValidatorsArraySize)
+	})
+
+	t.Run("should reject a value past the end of the slot", func(t *testing.T) {
+		layout.Storage[1].Offset = 25
+
+		_, err := DecodeWithLayout(account, layout, nil)
+		assert.ErrorIs(t, err, ErrInvalidLayoutEntry)
+	})
+}
@@ -0,0 +1,69 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployStakingSCForSolc(t *testing.T) {
+	var (
+		testVersion = "0.8.7-test"
+		testBuild   = StakingSCBuild{
+			Bytecode: "0x6080604052600080fd",
+			Slots: StorageSlots{
+				Validators:              10,
+				AddressToIsValidator:    11,
+				AddressToStakedAmount:   12,
+				AddressToValidatorIndex: 13,
+				StakedAmount:            14,
+				MinNumValidator:         15,
+				MaxNumValidator:         16,
+			},
+		}
+		validators = []types.Address{addr1, addr2}
+		params     = PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+	)
+
+	assert.NoError(t, RegisterStakingSCBuild(testVersion, testBuild))
+	assert.ErrorIs(t, RegisterStakingSCBuild(testVersion, testBuild), ErrSolcVersionRegistered)
+
+	defaultAccount, err := PredeployStakingSCForSolc(DefaultSolcVersion, validators, params)
+	assert.NoError(t, err)
+
+	testAccount, err := PredeployStakingSCForSolc(testVersion, validators, params)
+	assert.NoError(t, err)
+
+	// Each version has its own code
+	assert.Equal(t, hex.MustDecodeHex(StakingSCBytecode), defaultAccount.Code)
+	assert.Equal(t, hex.MustDecodeHex(testBuild.Bytecode), testAccount.Code)
+	assert.NotEqual(t, defaultAccount.Code, testAccount.Code)
+
+	// Each version has its own storage layout
+	assert.Equal(
+		t,
+		types.BytesToHash(big.NewInt(int64(params.MinValidatorCount)).Bytes()),
+		testAccount.Storage[types.BytesToHash(big.NewInt(testBuild.Slots.MinNumValidator).Bytes())],
+	)
+	assert.Equal(
+		t,
+		types.BytesToHash(big.NewInt(1).Bytes()),
+		testAccount.Storage[types.BytesToHash(getAddressMapping(addr2, testBuild.Slots.AddressToIsValidator))],
+	)
+	assert.Equal(t, defaultAccount.Balance, testAccount.Balance)
+
+	_, err = PredeployStakingSCForSolc("0.4.0", validators, params)
+	assert.ErrorIs(t, err, ErrUnknownSolcVersion)
+	assert.Contains(t, err.Error(), DefaultSolcVersion)
+	assert.Contains(t, err.Error(), testVersion)
+
+	build, err := StakingSCBuildForSolc(testVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, testBuild, build)
+
+	_, err = StakingSCBuildForSolc("0.4.0")
+	assert.ErrorIs(t, err, ErrUnknownSolcVersion)
+}
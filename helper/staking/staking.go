@@ -1,14 +1,19 @@
 package staking
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -17,20 +22,108 @@ var (
 	MaxValidatorCount = common.MaxSafeJSInt
 )
 
+// Hasher is the hash function used for calculating the storage indexes
+// of mappings and dynamic arrays. It can be replaced for testing the storage layout
+var Hasher = func(data []byte) []byte {
+	return keccak.Keccak256(nil, data)
+}
+
+var (
+	// MaxRewardPerBlock is the max block reward rate that can be predeployed (100 ETH)
+	MaxRewardPerBlock = new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))
+)
+
+var (
+	ErrPausingNotSupported    = errors.New("staking SC doesn't support pausing")
+	ErrRewardsNotSupported    = errors.New("staking SC doesn't support block rewards")
+	ErrInvalidRewardPerBlock  = errors.New("reward per block must be between zero and the max reward per block")
+	ErrEpochSizeNotSupported  = errors.New("staking SC doesn't record the epoch size")
+	ErrInvalidEpochSize       = errors.New("epoch size must be greater than zero")
+	ErrTooFewValidators       = errors.New("predeployed validator set is below the minimum number of validators")
+	ErrWordOverflow           = errors.New("value doesn't fit in the padding width")
+	ErrNegativeStake          = errors.New("stake must not be negative")
+	ErrStakeRoundedToZero     = errors.New("validator stake is less than a whole token")
+	ErrInvalidStartIndex      = errors.New("validators array start index must not be negative")
+	ErrValidatorsOverCapacity = errors.New("validators and reserved slots exceed the fixed validators array capacity")
+)
+
 // getAddressMapping returns the key for the SC storage mapping (address => something)
 //
 // More information:
 // https://docs.soliditylang.org/en/latest/internals/layout_in_storage.html
 func getAddressMapping(address types.Address, slot int64) []byte {
-	bigSlot := big.NewInt(slot)
+	return getWordMapping(types.BytesToHash(address.Bytes()), slot)
+}
 
-	finalSlice := append(
-		common.PadLeftOrTrim(address.Bytes(), 32),
-		common.PadLeftOrTrim(bigSlot.Bytes(), 32)...,
-	)
-	keccakValue := keccak.Keccak256(nil, finalSlice)
+// getMapping returns the key for the SC storage mapping (key => something),
+// calculated as keccak(key . slot). Keys longer than a word are rejected
+func getMapping(key []byte, slot int64) ([]byte, error) {
+	paddedKey, err := PadLeft32(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return getWordMapping(types.BytesToHash(paddedKey), slot), nil
+}
+
+// getWordMapping returns the key for the SC storage mapping (key => something),
+// for a key that is already a left-padded word
+func getWordMapping(key types.Hash, slot int64) []byte {
+	return Hasher(append(key.Bytes(), SlotKey(slot).Bytes()...))
+}
+
+// encodeStake encodes the stake as a uint256 storage value.
+// Negative stakes and stakes that don't fit in a word are rejected,
+// since they would otherwise be silently written as a different value
+func encodeStake(stake *big.Int) (types.Hash, error) {
+	if stake.Sign() < 0 {
+		return types.ZeroHash, fmt.Errorf("%w, got %s", ErrNegativeStake, stake)
+	}
+
+	if stake.BitLen() > 8*types.HashLength {
+		return types.ZeroHash, fmt.Errorf("%w, got %d bits", ErrWordOverflow, stake.BitLen())
+	}
+
+	return types.BytesToHash(stake.Bytes()), nil
+}
+
+// PadLeft32 left-pads the passed in byte array to a 32 byte word.
+// Unlike common.PadLeftOrTrim, longer input is an error instead of being trimmed,
+// since trimming a storage index input silently results in a different index
+func PadLeft32(b []byte) ([]byte, error) {
+	return padTo(b, types.HashLength)
+}
 
-	return keccakValue
+// padTo left-pads the passed in byte array to the given width,
+// returning an error if it is longer than the width
+func padTo(b []byte, width int) ([]byte, error) {
+	if len(b) > width {
+		return nil, fmt.Errorf("%w, got %d bytes, width %d", ErrWordOverflow, len(b), width)
+	}
+
+	return common.PadLeftOrTrim(b, width), nil
+}
+
+// StructMappingFieldIndex returns the storage index of a single field of a struct
+// that is the value type of a SC mapping (key => struct).
+// The struct is laid out starting at keccak(key . slot), with each field
+// located at fieldOffset slots from the start
+//
+// More information:
+// https://docs.soliditylang.org/en/latest/internals/layout_in_storage.html
+func StructMappingFieldIndex(key []byte, slot int64, fieldOffset int64) ([]byte, error) {
+	mappingIndex, err := getMapping(key, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	return getIndexWithOffset(mappingIndex, fieldOffset), nil
+}
+
+// SlotKey returns the storage key of a plain (non-mapping, non-array) SC state variable,
+// which is the slot number encoded as a left-padded 32 byte word
+func SlotKey(slot int64) types.Hash {
+	return types.BytesToHash(big.NewInt(slot).Bytes())
 }
 
 // getIndexWithOffset is a helper method for adding an offset to the already found keccak hash
@@ -49,38 +142,101 @@ func getIndexWithOffset(keccakHash []byte, offset int64) []byte {
 // It is SC dependant, and based on the SC located at:
 // https://github.com/0xPolygon/staking-contracts/
 func getStorageIndexes(address types.Address, index int64) *StorageIndexes {
+	return DefaultStorageSlots.storageIndexes(address, index)
+}
+
+// storageIndexes returns the indexes of the storage slots which need to be
+// modified during bootstrap, for the staking SC storage layout s
+func (s StorageSlots) storageIndexes(address types.Address, index int64) *StorageIndexes {
 	storageIndexes := StorageIndexes{}
 
 	// Get the indexes for the mappings
 	// The index for the mapping is retrieved with:
 	// keccak(address . slot)
 	// . stands for concatenation (basically appending the bytes)
-	storageIndexes.AddressToIsValidatorIndex = getAddressMapping(address, addressToIsValidatorSlot)
-	storageIndexes.AddressToStakedAmountIndex = getAddressMapping(address, addressToStakedAmountSlot)
-	storageIndexes.AddressToValidatorIndexIndex = getAddressMapping(address, addressToValidatorIndexSlot)
+	storageIndexes.AddressToIsValidatorIndex = getAddressMapping(address, s.AddressToIsValidator)
+	storageIndexes.AddressToStakedAmountIndex = getAddressMapping(address, s.AddressToStakedAmount)
+	storageIndexes.AddressToValidatorIndexIndex = getAddressMapping(address, s.AddressToValidatorIndex)
 
 	// Get the indexes for _validators, _stakedAmount
 	// Index for regular types is calculated as just the regular slot
-	storageIndexes.StakedAmountIndex = big.NewInt(stakedAmountSlot).Bytes()
+	storageIndexes.StakedAmountIndex = SlotKey(s.StakedAmount).Bytes()
 
-	// Index for array types is calculated as keccak(slot) + index
-	// The slot for the dynamic arrays that's put in the keccak needs to be in hex form (padded 64 chars)
-	storageIndexes.ValidatorsIndex = getIndexWithOffset(
-		keccak.Keccak256(nil, common.PadLeftOrTrim(big.NewInt(validatorsSlot).Bytes(), 32)),
-		index,
-	)
+	// Index for array types is calculated as keccak(slot) + index,
+	// and for index mappings as keccak(index . slot)
+	storageIndexes.ValidatorsIndex = s.validatorsArrayIndex(index)
 
 	// For any dynamic array in Solidity, the size of the actual array should be
 	// located on slot x
-	storageIndexes.ValidatorsArraySizeIndex = []byte{byte(validatorsSlot)}
+	storageIndexes.ValidatorsArraySizeIndex = SlotKey(s.Validators).Bytes()
 
 	return &storageIndexes
 }
 
+// validatorsArrayIndex returns the storage index of the validator at the given position,
+// depending on how the staking SC stores the validators
+func (s StorageSlots) validatorsArrayIndex(index int64) []byte {
+	switch s.ValidatorsKind {
+	case IndexMapping:
+		return getWordMapping(types.BytesToHash(big.NewInt(index).Bytes()), s.Validators)
+	case FixedArray:
+		return getIndexWithOffset(SlotKey(s.Validators).Bytes(), index)
+	default:
+		return getArrayElementIndex(s.Validators, index)
+	}
+}
+
+// getArrayElementIndex returns the storage index of the dynamic array element
+// at the given position, calculated as keccak(slot) + index
+func getArrayElementIndex(slot int64, index int64) []byte {
+	// The slot for the dynamic arrays that's put in the keccak needs to be in hex form (padded 64 chars)
+	return getIndexWithOffset(
+		Hasher(SlotKey(slot).Bytes()),
+		index,
+	)
+}
+
 // PredeployParams contains the values used to predeploy the PoS staking contract
 type PredeployParams struct {
 	MinValidatorCount uint64
 	MaxValidatorCount uint64
+
+	// Frozen pauses the staking SC, so stake / unstake revert at runtime
+	// and the validator set stays fixed at genesis.
+	// Requires a staking SC version that supports pausing
+	Frozen bool
+
+	// RewardPerBlock is the initial block reward rate of the staking SC, if set.
+	// Requires a staking SC version that distributes block rewards
+	RewardPerBlock *big.Int
+
+	// EpochSize is the number of blocks after which validator set changes take effect.
+	// Required by staking SC versions that record the epoch size, and not supported by others
+	EpochSize uint64
+
+	// SkipBalance registers the validators without their stakes, leaving the staked amounts
+	// and the staking SC balance at zero. It is meant only for tests that read the validator set,
+	// since the validators can't unstake and the stakes are not backed by any balance
+	SkipBalance bool
+
+	// RoundStakesToToken rounds each validator stake down to the nearest whole token (1e18 wei),
+	// so the staked amounts and the staking SC balance have no fractional tokens
+	RoundStakesToToken bool
+
+	// SortValidators writes the validators array in ascending address order,
+	// for consensus implementations that require a sorted validator set
+	SortValidators bool
+
+	// ReserveSlots is the number of empty validator slots reserved after the predeployed validators.
+	// For a FixedArray layout, the reserved element slots are written as zero, which documents
+	// the reserved capacity in the genesis storage without changing the state root.
+	// Other layouts grow dynamically, so nothing extra is written for them
+	ReserveSlots uint64
+
+	// DisableStaking predeploys the staking SC with the validator bounds, but without registering
+	// the passed in validators, so the staking SC account has no stakes and a zero balance.
+	// It is meant for PoA chains with a fixed validator set that switch to staking later
+	DisableStaking bool
 }
 
 // StorageIndexes is a wrapper for different storage indexes that
@@ -105,6 +261,104 @@ var (
 	maxNumValidatorSlot         = int64(6) // Slot 6
 )
 
+// ValidatorStorageKind is the way the staking SC stores the validator addresses
+type ValidatorStorageKind int
+
+const (
+	// DynamicArray stores the validators in an address[], with the elements at keccak(slot) + index
+	// and the array size at the slot
+	DynamicArray ValidatorStorageKind = iota
+
+	// IndexMapping stores the validators in a mapping(uint256 => address), with the elements
+	// at keccak(index . slot). The mapping has no size, so the validator count has to be kept separately
+	IndexMapping
+
+	// FixedArray stores the validators in an address[N], with the elements at slot + index.
+	// The array has no size slot, and its capacity N is set by the ValidatorsCapacity slot field
+	FixedArray
+)
+
+// StorageSlots are the slots of the staking SC state variables.
+// They depend on the SC source and on the compiler used to build it
+type StorageSlots struct {
+	Validators              int64 // address[]
+	AddressToIsValidator    int64 // mapping(address => bool)
+	AddressToStakedAmount   int64 // mapping(address => uint256)
+	AddressToValidatorIndex int64 // mapping(address => uint256)
+	StakedAmount            int64 // uint256
+	MinNumValidator         int64 // uint256
+	MaxNumValidator         int64 // uint256
+
+	// ValidatorsKind is the way the validators are stored at the Validators slot
+	ValidatorsKind ValidatorStorageKind
+
+	// ValidatorsCapacity is the length of the validators array, used only for a FixedArray layout
+	ValidatorsCapacity int64
+
+	// Paused is the slot of the paused flag (bool),
+	// nil if the staking SC doesn't support pausing
+	Paused *int64
+
+	// TotalValidators is the slot of the validator counter (uint256) kept
+	// separately from the validators array size, nil if the staking SC has no counter
+	TotalValidators *int64
+
+	// RewardPerBlock is the slot of the block reward rate (uint256),
+	// nil if the staking SC doesn't distribute block rewards
+	RewardPerBlock *int64
+
+	// EpochSize is the slot of the epoch size (uint256),
+	// nil if the staking SC doesn't record the epoch size
+	EpochSize *int64
+
+	// Token is the slot of the staking token metadata SC address (address),
+	// nil if the staking SC doesn't reference a token
+	Token *int64
+
+	// ValidatorsRoot is the slot of the Merkle root of the validator set (bytes32),
+	// nil if the staking SC doesn't store the root
+	ValidatorsRoot *int64
+
+	// AddressToCommission is the slot of the validator commission rates mapping(address => uint256),
+	// in basis points, nil if the staking SC doesn't support delegation commissions
+	AddressToCommission *int64
+
+	// ScheduledSets is the slot of the pre-scheduled validator sets mapping(uint256 => address[]),
+	// keyed by the epoch they take over at, nil if the staking SC doesn't support scheduled rotations
+	ScheduledSets *int64
+
+	// AddressToUnlockTime is the slot of the vesting unlock times mapping(address => uint256),
+	// nil if the staking SC doesn't support vesting
+	AddressToUnlockTime *int64
+
+	// AddressToWithdrawal is the slot of the withdrawal addresses mapping(address => address),
+	// nil if the staking SC doesn't support withdrawal addresses
+	AddressToWithdrawal *int64
+
+	// AddressToGroup is the slot of the validator group IDs mapping(address => uint256),
+	// nil if the staking SC doesn't group the validators
+	AddressToGroup *int64
+
+	// AddressToJoinBlock is the slot of the validator join blocks mapping(address => uint256),
+	// nil if the staking SC doesn't account rewards since the join block
+	AddressToJoinBlock *int64
+
+	// Delegations is the slot of the delegated amounts mapping(address => mapping(address => uint256)),
+	// keyed by validator then delegator, nil if the staking SC doesn't support delegations
+	Delegations *int64
+}
+
+// DefaultStorageSlots are the storage slots of the embedded staking SC
+var DefaultStorageSlots = StorageSlots{
+	Validators:              validatorsSlot,
+	AddressToIsValidator:    addressToIsValidatorSlot,
+	AddressToStakedAmount:   addressToStakedAmountSlot,
+	AddressToValidatorIndex: addressToValidatorIndexSlot,
+	StakedAmount:            stakedAmountSlot,
+	MinNumValidator:         minNumValidatorSlot,
+	MaxNumValidator:         maxNumValidatorSlot,
+}
+
 const (
 	DefaultStakedBalance = "0x8AC7230489E80000" // 10 ETH
 	//nolint: lll
@@ -117,13 +371,34 @@ func PredeployStakingSC(
 	validators []types.Address,
 	params PredeployParams,
 ) (*chain.GenesisAccount, error) {
-	// Set the code for the staking smart contract
-	// Code retrieved from https://github.com/0xPolygon/staking-contracts
-	scHex, _ := hex.DecodeHex(StakingSCBytecode)
-	stakingAccount := &chain.GenesisAccount{
-		Code: scHex,
+	stakedValidators, err := defaultStakedValidators(validators)
+	if err != nil {
+		return nil, err
 	}
 
+	return predeployStakingSC(defaultStakingSCBuild, stakedValidators, params)
+}
+
+// PredeployStakingSCFromIndex is PredeployStakingSC for validators appended to an already deployed
+// validator set (ex. during a migration), with the validators placed in the validators array from
+// startIndex on. The validators array size includes the startIndex validators before them,
+// while the total staked amount and the staking SC balance only cover the appended validators
+func PredeployStakingSCFromIndex(
+	validators []types.Address,
+	startIndex int64,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	stakedValidators, err := defaultStakedValidators(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	return predeployStakingSCAt(defaultStakingSCBuild, stakedValidators, startIndex, params, nil)
+}
+
+// defaultStakedValidators pre-stakes each of the passed in validators
+// with the default staked balance
+func defaultStakedValidators(validators []types.Address) ([]stakedValidator, error) {
 	// Parse the default staked balance value into *big.Int
 	val := DefaultStakedBalance
 	bigDefaultStakedBalance, err := types.ParseUint256orHex(&val)
@@ -132,59 +407,283 @@ func PredeployStakingSC(
 		return nil, fmt.Errorf("unable to generate DefaultStatkedBalance, %w", err)
 	}
 
+	stakedValidators := make([]stakedValidator, len(validators))
+	for indx, validator := range validators {
+		stakedValidators[indx] = stakedValidator{
+			address: validator,
+			stake:   bigDefaultStakedBalance,
+		}
+	}
+
+	return stakedValidators, nil
+}
+
+// stakedValidator is a validator that is pre-staked in the staking SC
+type stakedValidator struct {
+	address types.Address
+	stake   *big.Int
+}
+
+// predeployStakingSC sets up the staking smart contract account from the given build,
+// with each validator pre-staked with its own stake amount
+func predeployStakingSC(
+	build StakingSCBuild,
+	validators []stakedValidator,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	return predeployStakingSCWithHook(build, validators, params, nil)
+}
+
+// predeployStakingSCWithHook is predeployStakingSC, calling onWrite (if set)
+// for every write to the account storage
+func predeployStakingSCWithHook(
+	build StakingSCBuild,
+	validators []stakedValidator,
+	params PredeployParams,
+	onWrite func(StorageWrite),
+) (*chain.GenesisAccount, error) {
+	return predeployStakingSCAt(build, validators, 0, params, onWrite)
+}
+
+// registeredValidators returns the validators the way the predeploy writes them to the validators array,
+// so the staking SC features writing per-validator data cover the same validators in the same order
+func registeredValidators(validators []stakedValidator, params PredeployParams) []stakedValidator {
+	// The validators of a PoA chain are not derived from the stakes, so none are registered
+	if params.DisableStaking {
+		return nil
+	}
+
+	if params.SortValidators {
+		validators = append([]stakedValidator{}, validators...)
+
+		sort.Slice(validators, func(i, j int) bool {
+			return bytes.Compare(validators[i].address.Bytes(), validators[j].address.Bytes()) < 0
+		})
+	}
+
+	return validators
+}
+
+// predeployStakingSCWithAddressMapping is predeployStakingSC, with the value of each registered validator
+// written to the mapping (address => value) located at mappingSlot.
+// A nil mappingSlot means the build doesn't support the feature the mapping is for, which fails with errNotSupported
+func predeployStakingSCWithAddressMapping(
+	build StakingSCBuild,
+	validators []stakedValidator,
+	params PredeployParams,
+	mappingSlot *int64,
+	errNotSupported error,
+	values map[types.Address]types.Hash,
+) (*chain.GenesisAccount, error) {
+	if mappingSlot == nil {
+		return nil, errNotSupported
+	}
+
+	stakingAccount, err := predeployStakingSC(build, validators, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// The values of the validators that are not registered (ex. when staking is disabled) are left out
+	for _, validator := range registeredValidators(validators, params) {
+		stakingAccount.Storage[types.BytesToHash(getAddressMapping(validator.address, *mappingSlot))] =
+			values[validator.address]
+	}
+
+	return stakingAccount, nil
+}
+
+// predeployStakingSCAt is predeployStakingSCWithHook, with the validators placed in the validators array
+// from startIndex on. The array positions before startIndex are left to the validators already deployed
+func predeployStakingSCAt(
+	build StakingSCBuild,
+	validators []stakedValidator,
+	startIndex int64,
+	params PredeployParams,
+	onWrite func(StorageWrite),
+) (*chain.GenesisAccount, error) {
+	if startIndex < 0 {
+		return nil, fmt.Errorf("%w, got %d", ErrInvalidStartIndex, startIndex)
+	}
+
+	if err := build.Slots.checkSlotCollisions(); err != nil {
+		return nil, err
+	}
+
+	validators = registeredValidators(validators, params)
+
+	// The validators array size, including the validators before startIndex
+	arraySize := startIndex + int64(len(validators))
+
+	// The staking SC can be deployed without validators (ex. when switching from PoA to PoS),
+	// but a predeployed validator set below the minimum would prevent the chain from starting
+	if len(validators) > 0 && uint64(arraySize) < params.MinValidatorCount {
+		return nil, fmt.Errorf(
+			"%w, got %d validators, minimum is %d",
+			ErrTooFewValidators,
+			arraySize,
+			params.MinValidatorCount,
+		)
+	}
+
+	// Set the code for the staking smart contract
+	// The bytecode of registered builds is validated on registration
+	scHex, _ := hex.DecodeHex(build.Bytecode)
+
+	// The genesis code is never deployed through the EVM, so enforce the
+	// runtime code size limit (EIP-170) that applies to any other contract
+	if len(scHex) > state.SpuriousDragonMaxCodeSize {
+		return nil, fmt.Errorf("staking SC code is %d bytes, %w", len(scHex), runtime.ErrMaxCodeSizeExceeded)
+	}
+
+	stakingAccount := &chain.GenesisAccount{
+		Code: scHex,
+	}
+
 	// Generate the empty account storage map
-	storageMap := make(map[types.Hash]types.Hash)
+	storageMap := make(map[types.Hash]types.Hash, StorageEntryCount(len(validators)))
+	setStorage := func(key, value types.Hash) {
+		if onWrite != nil {
+			onWrite(StorageWrite{Key: key, Value: value})
+		}
+
+		storageMap[key] = value
+	}
+
 	bigTrueValue := big.NewInt(1)
 	stakedAmount := big.NewInt(0)
-	bigMinNumValidators := big.NewInt(int64(params.MinValidatorCount))
-	bigMaxNumValidators := big.NewInt(int64(params.MaxValidatorCount))
+
+	if build.Slots.ValidatorsKind == FixedArray {
+		if used := uint64(arraySize) + params.ReserveSlots; used > uint64(build.Slots.ValidatorsCapacity) {
+			return nil, fmt.Errorf(
+				"%w, %d slots used, capacity %d",
+				ErrValidatorsOverCapacity,
+				used,
+				build.Slots.ValidatorsCapacity,
+			)
+		}
+	}
 
 	for indx, validator := range validators {
+		if params.RoundStakesToToken {
+			validator.stake = new(big.Int).Sub(validator.stake, new(big.Int).Mod(validator.stake, weiPerEther))
+			if validator.stake.Sign() == 0 {
+				return nil, fmt.Errorf("%w, validator %s", ErrStakeRoundedToZero, validator.address)
+			}
+		}
+
 		// Update the total staked amount
-		stakedAmount.Add(stakedAmount, bigDefaultStakedBalance)
+		stakedAmount.Add(stakedAmount, validator.stake)
 
 		// Get the storage indexes
-		storageIndexes := getStorageIndexes(validator, int64(indx))
+		storageIndexes := build.Slots.storageIndexes(validator.address, startIndex+int64(indx))
 
 		// Set the value for the validators array
-		storageMap[types.BytesToHash(storageIndexes.ValidatorsIndex)] =
-			types.BytesToHash(
-				validator.Bytes(),
-			)
+		setStorage(
+			types.BytesToHash(storageIndexes.ValidatorsIndex),
+			types.BytesToHash(validator.address.Bytes()),
+		)
 
 		// Set the value for the address -> validator array index mapping
-		storageMap[types.BytesToHash(storageIndexes.AddressToIsValidatorIndex)] =
-			types.BytesToHash(bigTrueValue.Bytes())
+		setStorage(
+			types.BytesToHash(storageIndexes.AddressToIsValidatorIndex),
+			types.BytesToHash(bigTrueValue.Bytes()),
+		)
 
 		// Set the value for the address -> staked amount mapping
-		storageMap[types.BytesToHash(storageIndexes.AddressToStakedAmountIndex)] =
-			types.StringToHash(hex.EncodeBig(bigDefaultStakedBalance))
+		if !params.SkipBalance {
+			encodedStake, err := encodeStake(validator.stake)
+			if err != nil {
+				return nil, fmt.Errorf("invalid stake for validator %s, %w", validator.address, err)
+			}
+
+			setStorage(types.BytesToHash(storageIndexes.AddressToStakedAmountIndex), encodedStake)
+		}
 
 		// Set the value for the address -> validator index mapping
-		storageMap[types.BytesToHash(storageIndexes.AddressToValidatorIndexIndex)] =
-			types.StringToHash(hex.EncodeUint64(uint64(indx)))
+		setStorage(
+			types.BytesToHash(storageIndexes.AddressToValidatorIndexIndex),
+			types.StringToHash(hex.EncodeUint64(uint64(startIndex)+uint64(indx))),
+		)
+	}
+
+	// Without the stakes, the total staked amount and the balance stay at zero
+	if params.SkipBalance {
+		stakedAmount = big.NewInt(0)
+	}
 
+	if len(validators) > 0 {
 		// Set the value for the total staked amount
-		storageMap[types.BytesToHash(storageIndexes.StakedAmountIndex)] =
-			types.BytesToHash(stakedAmount.Bytes())
+		if !params.SkipBalance {
+			encodedStakedAmount, err := encodeStake(stakedAmount)
+			if err != nil {
+				return nil, fmt.Errorf("invalid total staked amount, %w", err)
+			}
+
+			setStorage(SlotKey(build.Slots.StakedAmount), encodedStakedAmount)
+		}
 
 		// Set the value for the size of the validators array
-		storageMap[types.BytesToHash(storageIndexes.ValidatorsArraySizeIndex)] =
-			types.StringToHash(hex.EncodeUint64(uint64(indx + 1)))
+		if build.Slots.ValidatorsKind == DynamicArray {
+			setStorage(SlotKey(build.Slots.Validators), types.StringToHash(hex.EncodeUint64(uint64(arraySize))))
+		}
+	}
+
+	// Zero out the reserved slots of the fixed validators array
+	if build.Slots.ValidatorsKind == FixedArray {
+		for indx := uint64(arraySize); indx < uint64(arraySize)+params.ReserveSlots; indx++ {
+			setStorage(types.BytesToHash(build.Slots.validatorsArrayIndex(int64(indx))), types.ZeroHash)
+		}
 	}
 
-	// Set the value for the minimum number of validators
-	storageMap[types.BytesToHash(big.NewInt(minNumValidatorSlot).Bytes())] =
-		types.BytesToHash(bigMinNumValidators.Bytes())
+	// Set the values for the minimum and maximum number of validators
+	build.Slots.setValidatorBounds(setStorage, params.MinValidatorCount, params.MaxValidatorCount)
+
+	// Set the value for the validator counter, which is kept equal to the validators array size
+	if build.Slots.TotalValidators != nil {
+		setStorage(
+			SlotKey(*build.Slots.TotalValidators),
+			types.BytesToHash(big.NewInt(arraySize).Bytes()),
+		)
+	}
 
-	// Set the value for the maximum number of validators
-	storageMap[types.BytesToHash(big.NewInt(maxNumValidatorSlot).Bytes())] =
-		types.BytesToHash(bigMaxNumValidators.Bytes())
+	// Pause the staking SC, so the validator set can't be changed by staking / unstaking
+	if params.Frozen {
+		if build.Slots.Paused == nil {
+			return nil, ErrPausingNotSupported
+		}
+
+		setStorage(SlotKey(*build.Slots.Paused), types.BytesToHash(big.NewInt(1).Bytes()))
+	}
+
+	// Set the value for the block reward rate
+	if params.RewardPerBlock != nil {
+		if build.Slots.RewardPerBlock == nil {
+			return nil, ErrRewardsNotSupported
+		}
+
+		if params.RewardPerBlock.Sign() < 0 || params.RewardPerBlock.Cmp(MaxRewardPerBlock) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRewardPerBlock, params.RewardPerBlock)
+		}
+
+		setStorage(SlotKey(*build.Slots.RewardPerBlock), types.BytesToHash(params.RewardPerBlock.Bytes()))
+	}
+
+	// Set the value for the epoch size
+	if build.Slots.EpochSize != nil {
+		if params.EpochSize == 0 {
+			return nil, ErrInvalidEpochSize
+		}
+
+		setStorage(SlotKey(*build.Slots.EpochSize), types.BytesToHash(new(big.Int).SetUint64(params.EpochSize).Bytes()))
+	} else if params.EpochSize != 0 {
+		return nil, ErrEpochSizeNotSupported
+	}
 
 	// Save the storage map
 	stakingAccount.Storage = storageMap
 
-	// Set the Staking SC balance to numValidators * defaultStakedBalance
+	// Set the Staking SC balance to the total staked amount
 	stakingAccount.Balance = stakedAmount
 
 	return stakingAccount, nil
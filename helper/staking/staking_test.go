@@ -0,0 +1,636 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+
+	// testMappingSlot is the slot of the optional mappings in the test builds
+	testMappingSlot = int64(7)
+
+	// testMappingKeys are the keys of addr1 and addr2 in a mapping (address => something) at slot 7,
+	// keccak(leftPad(address, 32) . leftPad(7, 32)), as computed by solc
+	testMappingKeys = map[types.Address]types.Hash{
+		addr1: types.StringToHash("0xb39221ace053465ec3453ce2b36430bd138b997ecea25c1043da0c366812b828"),
+		addr2: types.StringToHash("0xb7c774451310d1be4108bc180d1b52823cb0ee0274a6c0081bcaf94f115fb96d"),
+	}
+)
+
+func TestStructMappingFieldIndex(t *testing.T) {
+	// The storage keys solc lays out for addr1 in a mapping(address => Validator) at slot 7,
+	// with struct Validator { address addr; uint256 stake; uint256 index; } taking one slot per field
+	tests := []struct {
+		name     string
+		offset   int64
+		expected types.Hash
+	}{
+		{
+			"first field",
+			0,
+			types.StringToHash("0xb39221ace053465ec3453ce2b36430bd138b997ecea25c1043da0c366812b828"),
+		},
+		{
+			"second field",
+			1,
+			types.StringToHash("0xb39221ace053465ec3453ce2b36430bd138b997ecea25c1043da0c366812b829"),
+		},
+		{
+			"third field",
+			2,
+			types.StringToHash("0xb39221ace053465ec3453ce2b36430bd138b997ecea25c1043da0c366812b82a"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := StructMappingFieldIndex(addr1.Bytes(), testMappingSlot, tt.offset)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expected, types.BytesToHash(index))
+		})
+	}
+
+	// The stake of addr1 in the _addressToStakedAmount mapping of the embedded staking SC (slot 2)
+	// is the first field of a struct mapping at the same slot
+	index, err := StructMappingFieldIndex(addr1.Bytes(), addressToStakedAmountSlot, 0)
+	assert.NoError(t, err)
+
+	assert.Equal(
+		t,
+		types.StringToHash("0xe90b7bceb6e7df5418fb78d8ee546e97c83a08bbccc01a0644d599ccd2a7c2e0"),
+		types.BytesToHash(index),
+	)
+
+	// Keys longer than a word are rejected instead of being trimmed
+	_, err = StructMappingFieldIndex(make([]byte, 33), testMappingSlot, 0)
+	assert.ErrorIs(t, err, ErrWordOverflow)
+}
+
+func TestPadLeft32(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected []byte
+		err      error
+	}{
+		{
+			"exact length",
+			types.StringToHash("0x0102").Bytes(),
+			types.StringToHash("0x0102").Bytes(),
+			nil,
+		},
+		{
+			"short input",
+			[]byte{0x01, 0x02},
+			types.StringToHash("0x0102").Bytes(),
+			nil,
+		},
+		{
+			"empty input",
+			[]byte{},
+			types.ZeroHash.Bytes(),
+			nil,
+		},
+		{
+			"over-length input",
+			make([]byte, 33),
+			nil,
+			ErrWordOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			padded, err := PadLeft32(tt.input)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.expected, padded)
+		})
+	}
+}
+
+func TestPredeployStakingSC_Frozen(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10, Frozen: true}
+
+	stakedValidators, err := defaultStakedValidators([]types.Address{addr1, addr2})
+	assert.NoError(t, err)
+
+	t.Run("should set the paused slot", func(t *testing.T) {
+		pausedSlot := int64(7)
+		build := defaultStakingSCBuild
+		build.Slots.Paused = &pausedSlot
+
+		account, err := predeployStakingSC(build, stakedValidators, params)
+		assert.NoError(t, err)
+
+		assert.Equal(
+			t,
+			types.BytesToHash(big.NewInt(1).Bytes()),
+			account.Storage[types.BytesToHash(big.NewInt(pausedSlot).Bytes())],
+		)
+	})
+
+	t.Run("should fail when the SC doesn't support pausing", func(t *testing.T) {
+		_, err := PredeployStakingSC([]types.Address{addr1, addr2}, params)
+		assert.ErrorIs(t, err, ErrPausingNotSupported)
+	})
+}
+
+func TestPredeployStakingSC_MaxCodeSize(t *testing.T) {
+	stakedValidators, err := defaultStakedValidators([]types.Address{addr1})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		codeSize int
+		succeed  bool
+	}{
+		{"code at the size limit", state.SpuriousDragonMaxCodeSize, true},
+		{"code over the size limit", state.SpuriousDragonMaxCodeSize + 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			build := defaultStakingSCBuild
+			build.Bytecode = hex.EncodeToHex(make([]byte, tt.codeSize))
+
+			_, err := predeployStakingSC(build, stakedValidators, PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10})
+			if tt.succeed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, runtime.ErrMaxCodeSizeExceeded)
+			}
+		})
+	}
+}
+
+func TestHasher(t *testing.T) {
+	defaultHasher := Hasher
+
+	defer func() {
+		Hasher = defaultHasher
+	}()
+
+	var hashedInputs [][]byte
+
+	// The stub hasher returns a constant, and records the hashed inputs
+	Hasher = func(data []byte) []byte {
+		hashedInputs = append(hashedInputs, data)
+
+		return big.NewInt(100).Bytes()
+	}
+
+	storageIndexes := getStorageIndexes(addr1, 2)
+
+	assert.Equal(t, big.NewInt(100).Bytes(), storageIndexes.AddressToStakedAmountIndex)
+	assert.Equal(t, big.NewInt(102).Bytes(), storageIndexes.ValidatorsIndex)
+	assert.Len(t, hashedInputs, 4)
+
+	Hasher = defaultHasher
+
+	assert.Equal(
+		t,
+		keccak.Keccak256(nil, append(
+			types.BytesToHash(addr1.Bytes()).Bytes(),
+			types.BytesToHash(big.NewInt(addressToStakedAmountSlot).Bytes()).Bytes()...,
+		)),
+		getStorageIndexes(addr1, 2).AddressToStakedAmountIndex,
+	)
+}
+
+func TestPredeployStakingSC_RewardPerBlock(t *testing.T) {
+	rewardPerBlockSlot := int64(7)
+	build := defaultStakingSCBuild
+	build.Slots.RewardPerBlock = &rewardPerBlockSlot
+
+	stakedValidators, err := defaultStakedValidators([]types.Address{addr1})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		build          StakingSCBuild
+		rewardPerBlock *big.Int
+		err            error
+	}{
+		{"should set the reward rate", build, big.NewInt(1e18), nil},
+		{"should reject a negative reward rate", build, big.NewInt(-1), ErrInvalidRewardPerBlock},
+		{
+			"should reject a reward rate over the cap",
+			build,
+			new(big.Int).Add(MaxRewardPerBlock, big.NewInt(1)),
+			ErrInvalidRewardPerBlock,
+		},
+		{"should fail when the SC has no rewards", defaultStakingSCBuild, big.NewInt(1e18), ErrRewardsNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := predeployStakingSC(tt.build, stakedValidators, PredeployParams{
+				MinValidatorCount: 1,
+				MaxValidatorCount: 10,
+				RewardPerBlock:    tt.rewardPerBlock,
+			})
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(
+				t,
+				types.BytesToHash(tt.rewardPerBlock.Bytes()),
+				account.Storage[types.BytesToHash(big.NewInt(rewardPerBlockSlot).Bytes())],
+			)
+		})
+	}
+}
+
+func TestSlotKey(t *testing.T) {
+	var expected types.Hash
+
+	expected[types.HashLength-1] = 5
+
+	assert.Equal(t, expected, SlotKey(5))
+	assert.Equal(t, types.ZeroHash, SlotKey(validatorsSlot))
+	assert.Equal(t, types.BytesToHash(getStorageIndexes(addr1, 0).StakedAmountIndex), SlotKey(stakedAmountSlot))
+}
+
+func TestPredeployStakingSC_EpochSize(t *testing.T) {
+	epochSizeSlot := int64(7)
+	build := defaultStakingSCBuild
+	build.Slots.EpochSize = &epochSizeSlot
+
+	stakedValidators, err := defaultStakedValidators([]types.Address{addr1})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		build     StakingSCBuild
+		epochSize uint64
+		err       error
+	}{
+		{"should set the epoch size", build, 100, nil},
+		{"should reject a zero epoch size", build, 0, ErrInvalidEpochSize},
+		{"should fail when the SC has no epoch size", defaultStakingSCBuild, 100, ErrEpochSizeNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := predeployStakingSC(tt.build, stakedValidators, PredeployParams{
+				MinValidatorCount: 1,
+				MaxValidatorCount: 10,
+				EpochSize:         tt.epochSize,
+			})
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(
+				t,
+				types.BytesToHash(new(big.Int).SetUint64(tt.epochSize).Bytes()),
+				account.Storage[SlotKey(epochSizeSlot)],
+			)
+		})
+	}
+}
+
+func TestPredeployStakingSC_MinValidatorCount(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 3, MaxValidatorCount: 10}
+
+	tests := []struct {
+		name       string
+		validators []types.Address
+		err        error
+	}{
+		{"too few validators", []types.Address{addr1, addr2}, ErrTooFewValidators},
+		{"minimum number of validators", []types.Address{addr1, addr2, types.StringToAddress("3")}, nil},
+		{"no validators", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := PredeployStakingSC(tt.validators, params)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPredeployStakingSC_SkipBalance(t *testing.T) {
+	validators := []types.Address{addr1, addr2}
+
+	account, err := PredeployStakingSC(
+		validators,
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10, SkipBalance: true},
+	)
+	assert.NoError(t, err)
+
+	decodedValidators, err := DecodeValidators(account)
+	assert.NoError(t, err)
+	assert.Equal(t, validators, decodedValidators)
+
+	for indx, validator := range validators {
+		isValidator, err := IsValidatorInStorage(account.Storage, validator)
+		assert.NoError(t, err)
+		assert.True(t, isValidator)
+
+		storageIndexes := getStorageIndexes(validator, int64(indx))
+
+		assert.Equal(
+			t,
+			types.BytesToHash(big.NewInt(int64(indx)).Bytes()),
+			account.Storage[types.BytesToHash(storageIndexes.AddressToValidatorIndexIndex)],
+		)
+		assert.NotContains(t, account.Storage, types.BytesToHash(storageIndexes.AddressToStakedAmountIndex))
+	}
+
+	assert.Zero(t, decodeTotalStakedAmount(account).Sign())
+	assert.Zero(t, account.Balance.Sign())
+}
+
+func TestPredeployStakingSC_RoundStakesToToken(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10, RoundStakesToToken: true}
+
+	ether := func(amount float64) *big.Int {
+		wei, _ := new(big.Float).Mul(big.NewFloat(amount), big.NewFloat(1e18)).Int(nil)
+
+		return wei
+	}
+
+	t.Run("should floor the stakes", func(t *testing.T) {
+		account, err := predeployStakingSC(
+			defaultStakingSCBuild,
+			[]stakedValidator{
+				{address: addr1, stake: ether(10.75)},
+				{address: addr2, stake: ether(3.25)},
+			},
+			params,
+		)
+		assert.NoError(t, err)
+
+		stakedValidators, err := decodeStakedValidators(account)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []stakedValidator{
+			{address: addr1, stake: ether(10)},
+			{address: addr2, stake: ether(3)},
+		}, stakedValidators)
+
+		assert.Equal(t, ether(13), decodeTotalStakedAmount(account))
+		assert.Equal(t, ether(13), account.Balance)
+	})
+
+	t.Run("should reject a stake below a whole token", func(t *testing.T) {
+		_, err := predeployStakingSC(
+			defaultStakingSCBuild,
+			[]stakedValidator{{address: addr1, stake: ether(0.5)}},
+			params,
+		)
+		assert.ErrorIs(t, err, ErrStakeRoundedToZero)
+	})
+}
+
+func TestStorageSlots_ValidatorsKind(t *testing.T) {
+	arraySlots := DefaultStorageSlots
+
+	mappingSlots := DefaultStorageSlots
+	mappingSlots.ValidatorsKind = IndexMapping
+
+	// keccak(leftPad(index, 32) . leftPad(slot, 32))
+	expectedMappingIndex := keccak.Keccak256(nil, append(
+		types.BytesToHash(big.NewInt(1).Bytes()).Bytes(),
+		types.BytesToHash(big.NewInt(validatorsSlot).Bytes()).Bytes()...,
+	))
+
+	assert.Equal(t, getArrayElementIndex(validatorsSlot, 1), arraySlots.validatorsArrayIndex(1))
+	assert.Equal(t, expectedMappingIndex, mappingSlots.validatorsArrayIndex(1))
+	assert.NotEqual(t, arraySlots.validatorsArrayIndex(1), mappingSlots.validatorsArrayIndex(1))
+
+	t.Run("should predeploy the validators in an index mapping", func(t *testing.T) {
+		build := defaultStakingSCBuild
+		build.Slots = mappingSlots
+
+		stakedValidators, err := defaultStakedValidators([]types.Address{addr1, addr2})
+		assert.NoError(t, err)
+
+		account, err := predeployStakingSC(build, stakedValidators, PredeployParams{
+			MinValidatorCount: 1,
+			MaxValidatorCount: 10,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, types.BytesToHash(addr2.Bytes()), account.Storage[types.BytesToHash(expectedMappingIndex)])
+
+		// The mapping has no size
+		assert.NotContains(t, account.Storage, SlotKey(validatorsSlot))
+	})
+}
+
+func TestPredeployStakingSC_ReserveSlots(t *testing.T) {
+	stakedValidators, err := defaultStakedValidators([]types.Address{addr1, addr2})
+	assert.NoError(t, err)
+
+	t.Run("should zero out the reserved slots of a fixed array", func(t *testing.T) {
+		build := defaultStakingSCBuild
+		build.Slots.ValidatorsKind = FixedArray
+		build.Slots.Validators = 10
+		build.Slots.ValidatorsCapacity = 4
+
+		account, err := predeployStakingSC(build, stakedValidators, PredeployParams{
+			MinValidatorCount: 1,
+			MaxValidatorCount: 10,
+			ReserveSlots:      2,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, types.BytesToHash(addr1.Bytes()), account.Storage[SlotKey(10)])
+		assert.Equal(t, types.BytesToHash(addr2.Bytes()), account.Storage[SlotKey(11)])
+
+		for _, slot := range []int64{12, 13} {
+			value, ok := account.Storage[SlotKey(slot)]
+			assert.True(t, ok)
+			assert.Equal(t, types.ZeroHash, value)
+		}
+
+		assert.NotContains(t, account.Storage, SlotKey(14))
+	})
+
+	t.Run("should reject reserved slots over the fixed array capacity", func(t *testing.T) {
+		build := defaultStakingSCBuild
+		build.Slots.ValidatorsKind = FixedArray
+		build.Slots.Validators = 10
+		build.Slots.ValidatorsCapacity = 4
+
+		_, err := predeployStakingSC(build, stakedValidators, PredeployParams{
+			MinValidatorCount: 1,
+			MaxValidatorCount: 10,
+			ReserveSlots:      3,
+		})
+		assert.ErrorIs(t, err, ErrValidatorsOverCapacity)
+	})
+
+	t.Run("should not write anything extra for a dynamic array", func(t *testing.T) {
+		params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+		account, err := predeployStakingSC(defaultStakingSCBuild, stakedValidators, params)
+		assert.NoError(t, err)
+
+		params.ReserveSlots = 5
+
+		reservedAccount, err := predeployStakingSC(defaultStakingSCBuild, stakedValidators, params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, account.Storage, reservedAccount.Storage)
+	})
+}
+
+func TestPredeployStakingSCFromIndex(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	t.Run("should place the validators from the start index", func(t *testing.T) {
+		startIndex := int64(3)
+
+		account, err := PredeployStakingSCFromIndex([]types.Address{addr1, addr2}, startIndex, params)
+		assert.NoError(t, err)
+
+		for indx, validator := range []types.Address{addr1, addr2} {
+			storageIndexes := getStorageIndexes(validator, startIndex+int64(indx))
+
+			assert.Equal(
+				t,
+				types.BytesToHash(validator.Bytes()),
+				account.Storage[types.BytesToHash(storageIndexes.ValidatorsIndex)],
+			)
+			assert.Equal(
+				t,
+				types.BytesToHash(big.NewInt(startIndex+int64(indx)).Bytes()),
+				account.Storage[types.BytesToHash(storageIndexes.AddressToValidatorIndexIndex)],
+			)
+		}
+
+		// The positions before the start index are left to the deployed validators
+		assert.NotContains(t, account.Storage, types.BytesToHash(getArrayElementIndex(validatorsSlot, 0)))
+
+		assert.Equal(t, types.BytesToHash(big.NewInt(5).Bytes()), account.Storage[SlotKey(validatorsSlot)])
+	})
+
+	t.Run("should match the predeploy for a zero start index", func(t *testing.T) {
+		account, err := PredeployStakingSCFromIndex([]types.Address{addr1, addr2}, 0, params)
+		assert.NoError(t, err)
+
+		expected, err := PredeployStakingSC([]types.Address{addr1, addr2}, params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, account)
+	})
+
+	t.Run("should reject a negative start index", func(t *testing.T) {
+		_, err := PredeployStakingSCFromIndex([]types.Address{addr1}, -1, params)
+		assert.ErrorIs(t, err, ErrInvalidStartIndex)
+	})
+}
+
+func TestPredeployStakingSC_DisableStaking(t *testing.T) {
+	account, err := PredeployStakingSC([]types.Address{addr1, addr2}, PredeployParams{
+		MinValidatorCount: 1,
+		MaxValidatorCount: 10,
+		DisableStaking:    true,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, hex.MustDecodeHex(StakingSCBytecode), account.Code)
+	assert.Zero(t, account.Balance.Sign())
+
+	// Only the validator bounds are set
+	assert.Equal(t, map[types.Hash]types.Hash{
+		SlotKey(minNumValidatorSlot): types.BytesToHash(big.NewInt(1).Bytes()),
+		SlotKey(maxNumValidatorSlot): types.BytesToHash(big.NewInt(10).Bytes()),
+	}, account.Storage)
+
+	validators, err := DecodeValidators(account)
+	assert.NoError(t, err)
+	assert.Empty(t, validators)
+}
+
+func TestEncodeStake(t *testing.T) {
+	one := big.NewInt(1)
+
+	tests := []struct {
+		name  string
+		stake *big.Int
+		err   error
+	}{
+		{"255-bit stake", new(big.Int).Sub(new(big.Int).Lsh(one, 255), one), nil},
+		{"256-bit stake", new(big.Int).Sub(new(big.Int).Lsh(one, 256), one), nil},
+		{"257-bit stake", new(big.Int).Lsh(one, 256), ErrWordOverflow},
+		{"zero stake", big.NewInt(0), nil},
+		{"negative stake", big.NewInt(-5), ErrNegativeStake},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := encodeStake(tt.stake)
+			assert.ErrorIs(t, err, tt.err)
+
+			if tt.err == nil {
+				assert.Equal(t, tt.stake.String(), new(big.Int).SetBytes(encoded.Bytes()).String())
+			}
+		})
+	}
+
+	t.Run("should reject a total staked amount that doesn't fit in a word", func(t *testing.T) {
+		maxStake := new(big.Int).Sub(new(big.Int).Lsh(one, 256), one)
+
+		_, err := predeployStakingSC(
+			defaultStakingSCBuild,
+			[]stakedValidator{
+				{address: addr1, stake: maxStake},
+				{address: addr2, stake: maxStake},
+			},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.ErrorIs(t, err, ErrWordOverflow)
+	})
+}
+
+func TestPadTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		width    int
+		expected []byte
+		err      error
+	}{
+		{"width 32", []byte{0x01}, 32, types.BytesToHash([]byte{0x01}).Bytes(), nil},
+		{"width 20", []byte{0x01}, 20, types.BytesToAddress([]byte{0x01}).Bytes(), nil},
+		{"exact width 20", addr1.Bytes(), 20, addr1.Bytes(), nil},
+		{"over width 20", make([]byte, 21), 20, nil, ErrWordOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			padded, err := padTo(tt.input, tt.width)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.expected, padded)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package staking
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// requiredStorageSlots are the plain slots of the staking SC that are kept
+// in the storage even when zero, so the layout stays explicit in the genesis
+var requiredStorageSlots = []int64{
+	DefaultStorageSlots.Validators, // array size
+	DefaultStorageSlots.StakedAmount,
+	DefaultStorageSlots.MinNumValidator,
+	DefaultStorageSlots.MaxNumValidator,
+}
+
+// StorageEntryCount returns the number of storage entries the staking SC
+// predeploy creates for the given number of validators:
+// four per validator (array element, and the is validator, staked amount and index mappings),
+// the array size and the total staked amount if there are any validators,
+// and the minimum and maximum number of validators
+func StorageEntryCount(numValidators int) int {
+	count := numValidators*4 + 2
+
+	if numValidators > 0 {
+		count += 2
+	}
+
+	return count
+}
+
+// NormalizeStorage rebuilds the account storage map with canonical keys and values,
+// dropping zero value entries, since they are the implicit default in the EVM.
+// Zero values of the required plain slots (ex. the validators array size) are preserved
+func NormalizeStorage(account *chain.GenesisAccount) {
+	required := make(map[types.Hash]struct{}, len(requiredStorageSlots))
+	for _, slot := range requiredStorageSlots {
+		required[SlotKey(slot)] = struct{}{}
+	}
+
+	storageMap := make(map[types.Hash]types.Hash, len(account.Storage))
+
+	for key, value := range account.Storage {
+		key, value = types.BytesToHash(key.Bytes()), types.BytesToHash(value.Bytes())
+
+		if _, isRequired := required[key]; !isRequired && value == types.ZeroHash {
+			continue
+		}
+
+		storageMap[key] = value
+	}
+
+	account.Storage = storageMap
+}
+
+// RepairArraySize rewrites the validators array size of the staking SC account storage
+// to the number of populated array elements, counted from the start of the array
+// up to the first empty element. It returns whether the size was changed
+func RepairArraySize(account *chain.GenesisAccount) (bool, error) {
+	count := int64(0)
+
+	for {
+		value, ok := account.Storage[types.BytesToHash(DefaultStorageSlots.validatorsArrayIndex(count))]
+		if !ok || value == types.ZeroHash {
+			break
+		}
+
+		count++
+	}
+
+	sizeKey := SlotKey(DefaultStorageSlots.Validators)
+	if new(big.Int).SetBytes(account.Storage[sizeKey].Bytes()).Cmp(big.NewInt(count)) == 0 {
+		return false, nil
+	}
+
+	account.Storage[sizeKey] = types.BytesToHash(big.NewInt(count).Bytes())
+
+	return true, nil
+}
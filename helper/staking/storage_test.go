@@ -0,0 +1,100 @@
+package staking
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeStorage(t *testing.T) {
+	var (
+		arraySizeKey = types.BytesToHash(big.NewInt(validatorsSlot).Bytes())
+		minKey       = types.BytesToHash(big.NewInt(minNumValidatorSlot).Bytes())
+		zeroKey      = types.StringToHash("0xabcd")
+		valueKey     = types.StringToHash("0x1234")
+	)
+
+	account := &chain.GenesisAccount{
+		Storage: map[types.Hash]types.Hash{
+			arraySizeKey: types.ZeroHash,
+			minKey:       types.StringToHash("0x1"),
+			zeroKey:      types.ZeroHash,
+			valueKey:     types.StringToHash("0x2"),
+		},
+	}
+
+	NormalizeStorage(account)
+
+	assert.Equal(
+		t,
+		map[types.Hash]types.Hash{
+			arraySizeKey: types.ZeroHash,
+			minKey:       types.StringToHash("0x1"),
+			valueKey:     types.StringToHash("0x2"),
+		},
+		account.Storage,
+	)
+}
+
+func TestStorageEntryCount(t *testing.T) {
+	tests := []struct {
+		name          string
+		numValidators int
+		expected      int
+	}{
+		{"no validators", 0, 2},
+		{"single validator", 1, 8},
+		{"multiple validators", 10, 44},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StorageEntryCount(tt.numValidators))
+
+			validators := make([]types.Address, tt.numValidators)
+			for indx := range validators {
+				validators[indx] = types.BytesToAddress(big.NewInt(int64(indx + 1)).Bytes())
+			}
+
+			account, err := PredeployStakingSC(validators, PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10})
+			assert.NoError(t, err)
+			assert.Len(t, account.Storage, tt.expected)
+		})
+	}
+}
+
+func TestRepairArraySize(t *testing.T) {
+	validators := []types.Address{addr1, addr2}
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	t.Run("should not change a correct account", func(t *testing.T) {
+		account, err := PredeployStakingSC(validators, params)
+		assert.NoError(t, err)
+
+		changed, err := RepairArraySize(account)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	for _, size := range []int64{1, 5} {
+		t.Run(fmt.Sprintf("should repair a size of %d", size), func(t *testing.T) {
+			account, err := PredeployStakingSC(validators, params)
+			assert.NoError(t, err)
+
+			expected, err := PredeployStakingSC(validators, params)
+			assert.NoError(t, err)
+
+			account.Storage[SlotKey(validatorsSlot)] = types.BytesToHash(big.NewInt(size).Bytes())
+
+			changed, err := RepairArraySize(account)
+			assert.NoError(t, err)
+			assert.True(t, changed)
+
+			assert.Equal(t, expected.Storage, account.Storage)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package staking
+
+import (
+	"math/big"
+)
+
+// SupplyDeltaForValidatorChange returns the signed change to the staking SC balance
+// when a validator with the given stake is added (staked) or removed (unstaked).
+// The staked supply changes by the delta, and the circulating supply by its negation
+func SupplyDeltaForValidatorChange(stake *big.Int, adding bool) *big.Int {
+	delta := new(big.Int).Set(stake)
+	if !adding {
+		delta.Neg(delta)
+	}
+
+	return delta
+}
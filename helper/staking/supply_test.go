@@ -0,0 +1,30 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupplyDeltaForValidatorChange(t *testing.T) {
+	stake := big.NewInt(1000)
+
+	tests := []struct {
+		name     string
+		adding   bool
+		expected *big.Int
+	}{
+		{"adding a validator", true, big.NewInt(1000)},
+		{"removing a validator", false, big.NewInt(-1000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SupplyDeltaForValidatorChange(stake, tt.adding))
+
+			// The stake is not modified
+			assert.Equal(t, big.NewInt(1000), stake)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrThresholdMismatch   = errors.New("staking threshold doesn't match the embedded staking SC threshold")
+	ErrStakeBelowThreshold = errors.New("validator stake is below the staking threshold")
+)
+
+// EmbeddedStakingThreshold returns the minimum stake for becoming a validator (1 ETH),
+// which is hardcoded in the embedded staking SC bytecode.
+// A different threshold requires recompiling the staking SC
+func EmbeddedStakingThreshold() *big.Int {
+	return big.NewInt(1e18)
+}
+
+// AssertThreshold checks that the expected staking threshold is the one of the embedded staking SC
+func AssertThreshold(expected *big.Int) error {
+	if threshold := EmbeddedStakingThreshold(); expected == nil || expected.Cmp(threshold) != 0 {
+		return fmt.Errorf("%w, expected %s, embedded %s", ErrThresholdMismatch, expected, threshold)
+	}
+
+	return nil
+}
+
+// AssertStakesMeetThreshold checks that every validator stake is at least the threshold.
+// A nil threshold defaults to the embedded staking SC threshold
+func AssertStakesMeetThreshold(account *chain.GenesisAccount, threshold *big.Int) error {
+	if threshold == nil {
+		threshold = EmbeddedStakingThreshold()
+	}
+
+	stakedValidators, err := decodeStakedValidators(account)
+	if err != nil {
+		return err
+	}
+
+	belowThreshold := make([]types.Address, 0)
+
+	for _, validator := range stakedValidators {
+		if validator.stake.Cmp(threshold) < 0 {
+			belowThreshold = append(belowThreshold, validator.address)
+		}
+	}
+
+	if len(belowThreshold) > 0 {
+		return fmt.Errorf("%w %s, validators %v", ErrStakeBelowThreshold, threshold, belowThreshold)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package staking
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddedStakingThreshold(t *testing.T) {
+	threshold := EmbeddedStakingThreshold()
+
+	assert.Equal(t, big.NewInt(1e18), threshold)
+
+	// The threshold is pushed as an 8 byte value with PUSH8 (0x67) in the embedded bytecode
+	assert.True(t, strings.Contains(StakingSCBytecode, fmt.Sprintf("67%016x", threshold)))
+}
+
+func TestAssertThreshold(t *testing.T) {
+	assert.NoError(t, AssertThreshold(big.NewInt(1e18)))
+	assert.ErrorIs(t, AssertThreshold(big.NewInt(2e18)), ErrThresholdMismatch)
+	assert.ErrorIs(t, AssertThreshold(nil), ErrThresholdMismatch)
+}
+
+func TestAssertStakesMeetThreshold(t *testing.T) {
+	threshold := EmbeddedStakingThreshold()
+	belowThreshold := new(big.Int).Sub(threshold, big.NewInt(1))
+
+	t.Run("should accept stakes at the threshold", func(t *testing.T) {
+		account, err := PredeployStakingSCWithDistribution(
+			[]types.Address{addr1, addr2},
+			func(i int) *big.Int { return threshold },
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		assert.NoError(t, AssertStakesMeetThreshold(account, nil))
+	})
+
+	t.Run("should reject a stake below the threshold", func(t *testing.T) {
+		account, err := PredeployStakingSCWithDistribution(
+			[]types.Address{addr1, addr2},
+			func(i int) *big.Int {
+				if i == 1 {
+					return belowThreshold
+				}
+
+				return threshold
+			},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		err = AssertStakesMeetThreshold(account, nil)
+		assert.ErrorIs(t, err, ErrStakeBelowThreshold)
+		assert.Contains(t, err.Error(), addr2.String())
+		assert.NotContains(t, err.Error(), addr1.String())
+
+		// The stakes meet a lower custom threshold
+		assert.NoError(t, AssertStakesMeetThreshold(account, belowThreshold))
+	})
+}
@@ -0,0 +1,144 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrTokenNotSupported   = errors.New("staking SC doesn't reference a token")
+	ErrMissingTokenAddress = errors.New("token address is not set")
+	ErrTokenStringTooLong  = errors.New("token metadata string must be shorter than 32 bytes")
+	ErrTokenAddressTaken   = errors.New("token address is the staking SC address")
+	ErrTokenSlotCollision  = errors.New("token metadata state variables share a slot")
+)
+
+// TokenSlots are the slots of the token metadata SC state variables.
+// The name and symbol are strings shorter than 32 bytes, and the decimals are a uint8
+type TokenSlots struct {
+	Name     int64
+	Symbol   int64
+	Decimals int64
+}
+
+// DefaultTokenSlots is the layout of a token metadata SC declaring
+// string name; string symbol; uint8 decimals; as its first state variables
+var DefaultTokenSlots = TokenSlots{
+	Name:     0,
+	Symbol:   1,
+	Decimals: 2,
+}
+
+// TokenInfo is the native token metadata SC predeployed alongside the staking SC.
+// The metadata SC code is not embedded, so the compiled runtime code is passed in,
+// with the slots its metadata state variables are laid out at (ex. DefaultTokenSlots)
+type TokenInfo struct {
+	Address  types.Address
+	Code     []byte
+	Slots    TokenSlots
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+// PredeployStakingWithToken sets up the staking SC account of the given build with the passed in validators,
+// and the token metadata SC account it references. It returns both accounts, keyed by their address.
+// The build must store the token address (ex. a build registered with RegisterStakingSCBuild)
+func PredeployStakingWithToken(
+	build StakingSCBuild,
+	validators []types.Address,
+	tokenInfo TokenInfo,
+	params PredeployParams,
+) (map[types.Address]*chain.GenesisAccount, error) {
+	if build.Slots.Token == nil {
+		return nil, ErrTokenNotSupported
+	}
+
+	if tokenInfo.Address == types.ZeroAddress {
+		return nil, ErrMissingTokenAddress
+	}
+
+	// Both accounts are returned keyed by their address, so the token account would replace the staking SC
+	if tokenInfo.Address == stakingContracts.AddrStakingContract {
+		return nil, fmt.Errorf("%w, %s", ErrTokenAddressTaken, tokenInfo.Address)
+	}
+
+	tokenAccount, err := predeployToken(tokenInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	stakedValidators, err := defaultStakedValidators(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	stakingAccount, err := predeployStakingSC(build, stakedValidators, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the value for the token address
+	stakingAccount.Storage[SlotKey(*build.Slots.Token)] = types.BytesToHash(tokenInfo.Address.Bytes())
+
+	return map[types.Address]*chain.GenesisAccount{
+		stakingContracts.AddrStakingContract: stakingAccount,
+		tokenInfo.Address:                    tokenAccount,
+	}, nil
+}
+
+// predeployToken sets up the token metadata SC account
+func predeployToken(tokenInfo TokenInfo) (*chain.GenesisAccount, error) {
+	slots := tokenInfo.Slots
+	if slots.Name == slots.Symbol || slots.Name == slots.Decimals || slots.Symbol == slots.Decimals {
+		return nil, fmt.Errorf(
+			"%w, name %d, symbol %d, decimals %d",
+			ErrTokenSlotCollision,
+			slots.Name,
+			slots.Symbol,
+			slots.Decimals,
+		)
+	}
+
+	name, err := encodeShortString(tokenInfo.Name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token name, %w", err)
+	}
+
+	symbol, err := encodeShortString(tokenInfo.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token symbol, %w", err)
+	}
+
+	return &chain.GenesisAccount{
+		Code: tokenInfo.Code,
+		Storage: map[types.Hash]types.Hash{
+			SlotKey(slots.Name):     name,
+			SlotKey(slots.Symbol):   symbol,
+			SlotKey(slots.Decimals): types.BytesToHash(big.NewInt(int64(tokenInfo.Decimals)).Bytes()),
+		},
+	}, nil
+}
+
+// encodeShortString encodes a string shorter than 32 bytes the way Solidity stores it in a single slot:
+// the string bytes are left-aligned, and the last byte is twice the string length
+//
+// More information:
+// https://docs.soliditylang.org/en/latest/internals/layout_in_storage.html#bytes-and-string
+func encodeShortString(s string) (types.Hash, error) {
+	if len(s) >= types.HashLength {
+		return types.ZeroHash, fmt.Errorf("%w, got %d bytes", ErrTokenStringTooLong, len(s))
+	}
+
+	var value types.Hash
+
+	copy(value[:], s)
+	value[types.HashLength-1] = byte(len(s) * 2)
+
+	return value, nil
+}
@@ -0,0 +1,119 @@
+package staking
+
+import (
+	"strings"
+	"testing"
+
+	stakingContracts "github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployStakingWithToken(t *testing.T) {
+	tokenSlot := int64(7)
+	build := defaultStakingSCBuild
+	build.Slots.Token = &tokenSlot
+
+	tokenInfo := TokenInfo{
+		Address:  types.StringToAddress("1010"),
+		Code:     hex.MustDecodeHex("0x6080604052"),
+		Slots:    DefaultTokenSlots,
+		Name:     "Polygon",
+		Symbol:   "MATIC",
+		Decimals: 18,
+	}
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	validators := []types.Address{addr1, addr2}
+
+	t.Run("should predeploy both accounts", func(t *testing.T) {
+		accounts, err := PredeployStakingWithToken(build, validators, tokenInfo, params)
+		assert.NoError(t, err)
+		assert.Len(t, accounts, 2)
+
+		stakingAccount := accounts[stakingContracts.AddrStakingContract]
+		tokenAccount := accounts[tokenInfo.Address]
+
+		// The staking SC references the token
+		assert.Equal(t, types.BytesToHash(tokenInfo.Address.Bytes()), stakingAccount.Storage[SlotKey(tokenSlot)])
+
+		validators, err := DecodeValidators(stakingAccount)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.Address{addr1, addr2}, validators)
+
+		assert.Equal(t, tokenInfo.Code, tokenAccount.Code)
+		assert.Equal(t, map[types.Hash]types.Hash{
+			// "Polygon", with the length 7 * 2 in the last byte
+			SlotKey(0): types.StringToHash("0x506f6c79676f6e0000000000000000000000000000000000000000000000000e"),
+			// "MATIC", with the length 5 * 2 in the last byte
+			SlotKey(1): types.StringToHash("0x4d4154494300000000000000000000000000000000000000000000000000000a"),
+			SlotKey(2): types.BytesToHash([]byte{18}),
+		}, tokenAccount.Storage)
+	})
+
+	t.Run("should predeploy with a registered build", func(t *testing.T) {
+		assert.NoError(t, RegisterStakingSCBuild("0.8.7-token", build))
+
+		registeredBuild, err := StakingSCBuildForSolc("0.8.7-token")
+		assert.NoError(t, err)
+
+		accounts, err := PredeployStakingWithToken(registeredBuild, validators, tokenInfo, params)
+		assert.NoError(t, err)
+
+		assert.Equal(
+			t,
+			types.BytesToHash(tokenInfo.Address.Bytes()),
+			accounts[stakingContracts.AddrStakingContract].Storage[SlotKey(tokenSlot)],
+		)
+	})
+
+	t.Run("should reject a missing token address", func(t *testing.T) {
+		noAddress := tokenInfo
+		noAddress.Address = types.ZeroAddress
+
+		_, err := PredeployStakingWithToken(build, validators, noAddress, params)
+		assert.ErrorIs(t, err, ErrMissingTokenAddress)
+	})
+
+	t.Run("should reject the staking SC address as the token address", func(t *testing.T) {
+		stakingAddress := tokenInfo
+		stakingAddress.Address = stakingContracts.AddrStakingContract
+
+		_, err := PredeployStakingWithToken(build, validators, stakingAddress, params)
+		assert.ErrorIs(t, err, ErrTokenAddressTaken)
+	})
+
+	t.Run("should write the metadata at the passed in slots", func(t *testing.T) {
+		customSlots := tokenInfo
+		customSlots.Slots = TokenSlots{Name: 3, Symbol: 4, Decimals: 5}
+
+		accounts, err := PredeployStakingWithToken(build, validators, customSlots, params)
+		assert.NoError(t, err)
+
+		tokenAccount := accounts[tokenInfo.Address]
+		assert.Len(t, tokenAccount.Storage, 3)
+		assert.Equal(t, types.BytesToHash([]byte{18}), tokenAccount.Storage[SlotKey(5)])
+	})
+
+	t.Run("should reject colliding metadata slots", func(t *testing.T) {
+		collidingSlots := tokenInfo
+		collidingSlots.Slots = TokenSlots{Name: 0, Symbol: 1, Decimals: 1}
+
+		_, err := PredeployStakingWithToken(build, validators, collidingSlots, params)
+		assert.ErrorIs(t, err, ErrTokenSlotCollision)
+	})
+
+	t.Run("should reject a long token name", func(t *testing.T) {
+		longName := tokenInfo
+		longName.Name = strings.Repeat("a", 32)
+
+		_, err := PredeployStakingWithToken(build, validators, longName, params)
+		assert.ErrorIs(t, err, ErrTokenStringTooLong)
+	})
+
+	t.Run("should fail for the embedded staking SC", func(t *testing.T) {
+		_, err := PredeployStakingWithToken(defaultStakingSCBuild, validators, tokenInfo, params)
+		assert.ErrorIs(t, err, ErrTokenNotSupported)
+	})
+}
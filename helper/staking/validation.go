@@ -0,0 +1,473 @@
+package staking
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// MaxInitCodeSize is the max size of the contract init code (EIP-3860)
+const MaxInitCodeSize = 2 * state.SpuriousDragonMaxCodeSize
+
+var (
+	ErrNonCanonicalStorageValue = errors.New("storage value is not a left-padded 32 byte word")
+	ErrSnapshotMismatch         = errors.New("staking SC validators don't match the consensus snapshot")
+	ErrTotalValidatorsMismatch  = errors.New("validator counter doesn't match the validators array size")
+	ErrBalanceBelowStake        = errors.New("staking SC balance is lower than the total staked amount")
+	ErrBalanceNotEqualStake     = errors.New("staking SC balance is not equal to the sum of the validator stakes")
+	ErrDuplicateValidatorIndex  = errors.New("validators share the same array index")
+	ErrUnexpectedStorageSlot    = errors.New("storage slot is not written by the staking SC predeploy")
+	ErrValidatorsNotSorted      = errors.New("validators are not sorted by address")
+	ErrValidatorIndexMismatch   = errors.New("validator index doesn't match its position in the validators array")
+	ErrUnstakedValidator        = errors.New("validator has no staked amount")
+	ErrValidatorFlagMismatch    = errors.New("validator flag doesn't match the validators array")
+	ErrValidatorSlotGap         = errors.New("validators array elements are not contiguous")
+	ErrRewardBufferTooLarge     = errors.New("staking SC reward buffer exceeds the max reward buffer")
+	ErrMissingContractCode      = errors.New("contract account has no code")
+	ErrContractNonceMismatch    = errors.New("contract account nonce doesn't match the expected nonce")
+	ErrEmptyStorageRoot         = errors.New("contract account storage is set, but its storage root is empty")
+	ErrMaxInitCodeSizeExceeded  = errors.New("init code exceeds the max init code size")
+)
+
+// ValidateCanonicalStorage checks the values of the staking SC storage against the type of their slot
+// in the storage layout, to catch values aligned to the wrong side of the word or truncated:
+//
+//   - the validators array elements are addresses, so the 12 high bytes must be empty,
+//     and a zero address is flagged as truncated
+//   - the validator flags are bools, so the value must be 0 or 1
+//
+// Any 32 byte word is a valid uint256 or bytes32 (ex. a 2^255 stake or a Merkle root ending in 0x00),
+// so the values of the other slots are not checked
+func ValidateCanonicalStorage(account *chain.GenesisAccount) error {
+	numValidators, err := decodeValidatorsArraySize(account)
+	if err != nil {
+		return err
+	}
+
+	for indx := 0; indx < numValidators; indx++ {
+		key := types.BytesToHash(DefaultStorageSlots.validatorsArrayIndex(int64(indx)))
+
+		value, ok := account.Storage[key]
+		if !ok {
+			// Missing elements are reported by DecodeValidators
+			continue
+		}
+
+		if !bytes.Equal(value[:types.HashLength-types.AddressLength], zeroPadding) {
+			return fmt.Errorf("%w, address key %s value %s is not left-padded", ErrNonCanonicalStorageValue, key, value)
+		}
+
+		validator := types.BytesToAddress(value.Bytes())
+		if validator == types.ZeroAddress {
+			return fmt.Errorf("%w, address key %s value is truncated to zero", ErrNonCanonicalStorageValue, key)
+		}
+
+		if _, err := IsValidatorInStorage(account.Storage, validator); err != nil {
+			return fmt.Errorf("%w, %v", ErrNonCanonicalStorageValue, err)
+		}
+	}
+
+	return nil
+}
+
+// CrossCheckWithSnapshot checks that the validators of the staking SC account
+// are the same set as the validators of the consensus snapshot (regardless of order)
+func CrossCheckWithSnapshot(account *chain.GenesisAccount, snapshotValidators []types.Address) error {
+	stakingValidators, err := DecodeValidators(account)
+	if err != nil {
+		return err
+	}
+
+	onlyInStaking := addressSetDifference(stakingValidators, snapshotValidators)
+	onlyInSnapshot := addressSetDifference(snapshotValidators, stakingValidators)
+
+	if len(onlyInStaking) != 0 || len(onlyInSnapshot) != 0 {
+		return fmt.Errorf(
+			"%w, only in staking SC: %v, only in snapshot: %v",
+			ErrSnapshotMismatch,
+			onlyInStaking,
+			onlyInSnapshot,
+		)
+	}
+
+	return nil
+}
+
+// AssertValidatorBooleanConsistency checks that every address in the validators array is flagged
+// in the address -> is validator mapping, and that no address outside of the array is flagged.
+//
+// The mapping keys are hashed, so the addresses outside of the array can't be listed from the storage.
+// Instead, every storage value that can hold an address is checked, which covers the addresses left
+// behind in the array elements past the array size by a partially applied validator removal
+func AssertValidatorBooleanConsistency(account *chain.GenesisAccount) error {
+	validators, err := DecodeValidators(account)
+	if err != nil {
+		return err
+	}
+
+	inArray := make(map[types.Address]bool, len(validators))
+
+	for _, validator := range validators {
+		inArray[validator] = true
+
+		isValidator, err := IsValidatorInStorage(account.Storage, validator)
+		if err != nil {
+			return err
+		}
+
+		if !isValidator {
+			return fmt.Errorf("%w, validator %s in the array is not flagged", ErrValidatorFlagMismatch, validator)
+		}
+	}
+
+	for _, key := range sortedStorageKeys(account.Storage) {
+		value := account.Storage[key]
+
+		// Addresses are stored left-padded, so the upper 12 bytes are empty
+		if value == types.ZeroHash || !bytes.Equal(value[:types.HashLength-types.AddressLength], zeroPadding) {
+			continue
+		}
+
+		address := types.BytesToAddress(value.Bytes())
+		if inArray[address] {
+			continue
+		}
+
+		isValidator, err := IsValidatorInStorage(account.Storage, address)
+		if err != nil {
+			return err
+		}
+
+		if isValidator {
+			return fmt.Errorf("%w, address %s is flagged, but not in the array", ErrValidatorFlagMismatch, address)
+		}
+	}
+
+	return nil
+}
+
+// zeroPadding is the left padding of an address stored in a 32 byte word
+var zeroPadding = make([]byte, types.HashLength-types.AddressLength)
+
+// addressSetDifference returns the addresses of a that are not in b
+func addressSetDifference(a, b []types.Address) []types.Address {
+	inB := make(map[types.Address]struct{}, len(b))
+	for _, addr := range b {
+		inB[addr] = struct{}{}
+	}
+
+	difference := make([]types.Address, 0)
+
+	for _, addr := range a {
+		if _, ok := inB[addr]; !ok {
+			difference = append(difference, addr)
+		}
+	}
+
+	return difference
+}
+
+// ValidateTotalValidators checks that the validator counter of the staking SC storage layout
+// is equal to the validators array size. Layouts without a counter are always valid
+func ValidateTotalValidators(account *chain.GenesisAccount, slots StorageSlots) error {
+	if slots.TotalValidators == nil {
+		return nil
+	}
+
+	arraySize := new(big.Int).SetBytes(account.Storage[SlotKey(slots.Validators)].Bytes())
+	totalValidators := new(big.Int).SetBytes(account.Storage[SlotKey(*slots.TotalValidators)].Bytes())
+
+	if arraySize.Cmp(totalValidators) != 0 {
+		return fmt.Errorf(
+			"%w, array size %s, counter %s",
+			ErrTotalValidatorsMismatch,
+			arraySize,
+			totalValidators,
+		)
+	}
+
+	return nil
+}
+
+// AssertBalanceCoversStake checks that the staking SC account balance is at least
+// the total staked amount, since the SC must hold the staked principal to honor withdrawals
+func AssertBalanceCoversStake(account *chain.GenesisAccount) error {
+	balance := accountBalance(account)
+	totalStaked := decodeTotalStakedAmount(account)
+
+	if balance.Cmp(totalStaked) < 0 {
+		return fmt.Errorf("%w, balance %s, total staked %s", ErrBalanceBelowStake, balance, totalStaked)
+	}
+
+	return nil
+}
+
+// AssertWithdrawalSolvency checks that the staking SC can honor the withdrawal of every stake,
+// so the balance is at least the total staked amount. The balance over the total staked amount is the
+// reward buffer, which must not exceed maxRewardBuffer, if set
+func AssertWithdrawalSolvency(account *chain.GenesisAccount, maxRewardBuffer *big.Int) error {
+	if err := AssertBalanceCoversStake(account); err != nil {
+		return err
+	}
+
+	if maxRewardBuffer == nil {
+		return nil
+	}
+
+	rewardBuffer := new(big.Int).Sub(accountBalance(account), decodeTotalStakedAmount(account))
+
+	if rewardBuffer.Cmp(maxRewardBuffer) > 0 {
+		return fmt.Errorf(
+			"%w, reward buffer %s, max reward buffer %s",
+			ErrRewardBufferTooLarge,
+			rewardBuffer,
+			maxRewardBuffer,
+		)
+	}
+
+	return nil
+}
+
+// AssertBalanceEqualsStake checks that the staking SC account balance is exactly the sum
+// of the validator stakes, for chains where the SC holds no funds other than the staked principal
+func AssertBalanceEqualsStake(account *chain.GenesisAccount) error {
+	stakedValidators, err := decodeStakedValidators(account)
+	if err != nil {
+		return err
+	}
+
+	stakeSum := big.NewInt(0)
+	for _, validator := range stakedValidators {
+		stakeSum.Add(stakeSum, validator.stake)
+	}
+
+	if balance := accountBalance(account); balance.Cmp(stakeSum) != 0 {
+		return fmt.Errorf("%w, balance %s, stakes sum %s", ErrBalanceNotEqualStake, balance, stakeSum)
+	}
+
+	return nil
+}
+
+// AssertAllValidatorsStaked checks that every validator in the validators array has a positive
+// staked amount, since the staking SC removes a validator once its stake is withdrawn
+func AssertAllValidatorsStaked(account *chain.GenesisAccount) error {
+	stakedValidators, err := decodeStakedValidators(account)
+	if err != nil {
+		return err
+	}
+
+	unstaked := make([]types.Address, 0)
+
+	for _, validator := range stakedValidators {
+		if validator.stake.Sign() <= 0 {
+			unstaked = append(unstaked, validator.address)
+		}
+	}
+
+	if len(unstaked) > 0 {
+		return fmt.Errorf("%w, validators %v", ErrUnstakedValidator, unstaked)
+	}
+
+	return nil
+}
+
+// CheckUniqueValidatorIndexes checks that the validator index mapping of the staking SC storage
+// is a bijection between the validators array positions and the validator addresses
+func CheckUniqueValidatorIndexes(account *chain.GenesisAccount) error {
+	validators, err := DecodeValidators(account)
+	if err != nil {
+		return err
+	}
+
+	indexToValidator := make(map[uint64]types.Address, len(validators))
+
+	for position, validator := range validators {
+		rawIndex := new(big.Int).SetBytes(
+			account.Storage[types.BytesToHash(getAddressMapping(validator, addressToValidatorIndexSlot))].Bytes(),
+		)
+
+		if !rawIndex.IsUint64() {
+			return fmt.Errorf("%w, validator %s, index %s", ErrValidatorIndexMismatch, validator, rawIndex)
+		}
+
+		index := rawIndex.Uint64()
+
+		if other, ok := indexToValidator[index]; ok {
+			return fmt.Errorf("%w, index %d, validators %s and %s", ErrDuplicateValidatorIndex, index, other, validator)
+		}
+
+		if index != uint64(position) {
+			return fmt.Errorf("%w, validator %s, index %d, position %d", ErrValidatorIndexMismatch, validator, index, position)
+		}
+
+		indexToValidator[index] = validator
+	}
+
+	return nil
+}
+
+// AssertNoExtraSlots checks that the staking SC account storage has no keys other than the ones
+// written when predeploying the staking SC with the passed in validators and params
+func AssertNoExtraSlots(
+	account *chain.GenesisAccount,
+	validators []types.Address,
+	params PredeployParams,
+) error {
+	stakedValidators, err := defaultStakedValidators(validators)
+	if err != nil {
+		return err
+	}
+
+	expectedKeys := make(map[types.Hash]struct{}, StorageEntryCount(len(validators)))
+
+	if _, err := predeployStakingSCWithHook(
+		defaultStakingSCBuild,
+		stakedValidators,
+		params,
+		func(write StorageWrite) {
+			expectedKeys[write.Key] = struct{}{}
+		},
+	); err != nil {
+		return err
+	}
+
+	for key := range account.Storage {
+		if _, ok := expectedKeys[key]; !ok {
+			return fmt.Errorf("%w, key %s", ErrUnexpectedStorageSlot, key)
+		}
+	}
+
+	return nil
+}
+
+// AssertValidatorsSorted checks that the validators array of the staking SC account storage
+// is sorted in ascending address order
+func AssertValidatorsSorted(account *chain.GenesisAccount) error {
+	validators, err := DecodeValidators(account)
+	if err != nil {
+		return err
+	}
+
+	for indx := 1; indx < len(validators); indx++ {
+		if bytes.Compare(validators[indx-1].Bytes(), validators[indx].Bytes()) >= 0 {
+			return fmt.Errorf(
+				"%w, %s at index %d is not before %s at index %d",
+				ErrValidatorsNotSorted,
+				validators[indx-1],
+				indx-1,
+				validators[indx],
+				indx,
+			)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCodeLimits checks the account code, and the init code it is deployed with, against the code size
+// limits. initCode is the creation bytecode, nil for an account predeployed with its runtime code.
+//
+// The runtime code size limit (EIP-170) is enforced by the executor only when EIP-158 is active,
+// so the same fork gates the check here. The EVM doesn't implement the init code size limit (EIP-3860),
+// so it is checked only when enforceInitCodeLimit is set, ex. for code that is also deployed on a chain that has it
+func ValidateCodeLimits(
+	account *chain.GenesisAccount,
+	initCode []byte,
+	forks chain.ForksInTime,
+	enforceInitCodeLimit bool,
+) error {
+	if forks.EIP158 && len(account.Code) > state.SpuriousDragonMaxCodeSize {
+		return fmt.Errorf(
+			"code is %d bytes, limit is %d bytes, %w",
+			len(account.Code),
+			state.SpuriousDragonMaxCodeSize,
+			runtime.ErrMaxCodeSizeExceeded,
+		)
+	}
+
+	if enforceInitCodeLimit && len(initCode) > MaxInitCodeSize {
+		return fmt.Errorf(
+			"%w, init code is %d bytes, limit is %d bytes",
+			ErrMaxInitCodeSizeExceeded,
+			len(initCode),
+			MaxInitCodeSize,
+		)
+	}
+
+	return nil
+}
+
+// AssertContiguousValidatorSlots checks that the validators array elements 0..N-1 hold non-zero addresses,
+// and that no element is populated past the array size N. A gap usually comes from a bad validator removal
+// or import. Every storage key is checked against the array element range, so no populated element is missed
+func AssertContiguousValidatorSlots(account *chain.GenesisAccount) error {
+	validators, err := DecodeValidators(account)
+	if err != nil {
+		return err
+	}
+
+	for indx, validator := range validators {
+		if validator == types.ZeroAddress {
+			return fmt.Errorf("%w, element %d is empty", ErrValidatorSlotGap, indx)
+		}
+	}
+
+	arrayStart := new(big.Int).SetBytes(getArrayElementIndex(DefaultStorageSlots.Validators, 0))
+	arraySize := big.NewInt(int64(len(validators)))
+	maxIndex := new(big.Int).SetUint64(MaxValidatorCount)
+
+	for _, key := range sortedStorageKeys(account.Storage) {
+		if account.Storage[key] == types.ZeroHash {
+			continue
+		}
+
+		// The element index is the key offset from the start of the array
+		index := new(big.Int).Sub(new(big.Int).SetBytes(key.Bytes()), arrayStart)
+		if index.Cmp(arraySize) >= 0 && index.Cmp(maxIndex) <= 0 {
+			return fmt.Errorf(
+				"%w, element %s is populated past the array size %d",
+				ErrValidatorSlotGap,
+				index,
+				len(validators),
+			)
+		}
+	}
+
+	return nil
+}
+
+// AssertContractAccountShape checks that the account is shaped like a deployed contract: it has code,
+// its nonce is expectedNonce, and if its storage is set, the storage root is not the empty root.
+// The expected nonce depends on the chain convention, ex. 1 for accounts modeled as created
+// by CREATE (EIP-161), 0 for accounts only injected at genesis, like the staking SC predeploy.
+// A storage made only of zero values has the empty root, since zero values are not stored in the trie
+func AssertContractAccountShape(account *chain.GenesisAccount, expectedNonce uint64) error {
+	if len(account.Code) == 0 {
+		return ErrMissingContractCode
+	}
+
+	if account.Nonce != expectedNonce {
+		return fmt.Errorf("%w, expected %d, got %d", ErrContractNonceMismatch, expectedNonce, account.Nonce)
+	}
+
+	if len(account.Storage) == 0 {
+		return nil
+	}
+
+	root, err := StorageRoot(account.Storage)
+	if err != nil {
+		return err
+	}
+
+	if root == types.EmptyRootHash {
+		return fmt.Errorf("%w, %d storage slots", ErrEmptyStorageRoot, len(account.Storage))
+	}
+
+	return nil
+}
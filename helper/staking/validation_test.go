@@ -0,0 +1,618 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCanonicalStorage(t *testing.T) {
+	newAccount := func(t *testing.T) *chain.GenesisAccount {
+		t.Helper()
+
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		return account
+	}
+
+	t.Run("should accept the predeployed storage", func(t *testing.T) {
+		assert.NoError(t, ValidateCanonicalStorage(newAccount(t)))
+	})
+
+	t.Run("should accept a stake using the full word", func(t *testing.T) {
+		// 2^255 has its most significant byte set, and its least significant byte empty
+		stake := new(big.Int).Lsh(big.NewInt(1), 255)
+
+		account, err := PredeployStakingSCWithDistribution(
+			[]types.Address{addr1},
+			func(int) *big.Int { return stake },
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		assert.NoError(t, ValidateCanonicalStorage(account))
+	})
+
+	t.Run("should accept a Merkle root ending in 0x00", func(t *testing.T) {
+		rootSlot := int64(7)
+
+		build := defaultStakingSCBuild
+		build.Slots.ValidatorsRoot = &rootSlot
+
+		account, err := predeployStakingSC(build, nil, PredeployParams{})
+		assert.NoError(t, err)
+
+		account.Storage[SlotKey(rootSlot)] = types.StringToHash(
+			"0xff0000000000000000000000000000000000000000000000000000000000aa00",
+		)
+
+		assert.NoError(t, ValidateCanonicalStorage(account))
+	})
+
+	tests := []struct {
+		name  string
+		key   func() types.Hash
+		value types.Hash
+	}{
+		{
+			// The validator address aligned to the left of the word
+			"right-padded address",
+			func() types.Hash { return types.BytesToHash(getStorageIndexes(addr1, 0).ValidatorsIndex) },
+			types.StringToHash("0xff00000000000000000000000000000000000001000000000000000000000000"),
+		},
+		{
+			"address truncated to zero",
+			func() types.Hash { return types.BytesToHash(getStorageIndexes(addr2, 1).ValidatorsIndex) },
+			types.ZeroHash,
+		},
+		{
+			"bool over 1",
+			func() types.Hash { return types.BytesToHash(getStorageIndexes(addr2, 1).AddressToIsValidatorIndex) },
+			types.BytesToHash([]byte{2}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("should detect a "+tt.name, func(t *testing.T) {
+			account := newAccount(t)
+			account.Storage[tt.key()] = tt.value
+
+			assert.ErrorIs(t, ValidateCanonicalStorage(account), ErrNonCanonicalStorageValue)
+		})
+	}
+}
+
+func TestCrossCheckWithSnapshot(t *testing.T) {
+	account, err := PredeployStakingSC(
+		[]types.Address{addr1, addr2},
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		snapshot []types.Address
+		succeed  bool
+	}{
+		{"same set", []types.Address{addr1, addr2}, true},
+		{"same set in a different order", []types.Address{addr2, addr1}, true},
+		{"missing validator in the snapshot", []types.Address{addr1}, false},
+		{"extra validator in the snapshot", []types.Address{addr1, addr2, types.StringToAddress("3")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CrossCheckWithSnapshot(account, tt.snapshot)
+			if tt.succeed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrSnapshotMismatch)
+			}
+		})
+	}
+}
+
+func TestValidateTotalValidators(t *testing.T) {
+	totalValidatorsSlot := int64(7)
+	build := defaultStakingSCBuild
+	build.Slots.TotalValidators = &totalValidatorsSlot
+
+	stakedValidators, err := defaultStakedValidators([]types.Address{addr1, addr2})
+	assert.NoError(t, err)
+
+	account, err := predeployStakingSC(
+		build,
+		stakedValidators,
+		PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(
+		t,
+		types.BytesToHash(big.NewInt(2).Bytes()),
+		account.Storage[types.BytesToHash(big.NewInt(totalValidatorsSlot).Bytes())],
+	)
+	assert.NoError(t, ValidateTotalValidators(account, build.Slots))
+
+	account.Storage[types.BytesToHash(big.NewInt(totalValidatorsSlot).Bytes())] =
+		types.BytesToHash(big.NewInt(3).Bytes())
+	assert.ErrorIs(t, ValidateTotalValidators(account, build.Slots), ErrTotalValidatorsMismatch)
+
+	// Layouts without a counter are not checked
+	assert.NoError(t, ValidateTotalValidators(account, DefaultStorageSlots))
+}
+
+func TestAssertBalanceCoversStake(t *testing.T) {
+	tests := []struct {
+		name    string
+		delta   int64
+		succeed bool
+	}{
+		{"balance equal to the total staked", 0, true},
+		{"balance greater than the total staked", 1, true},
+		{"balance less than the total staked", -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := PredeployStakingSC(
+				[]types.Address{addr1, addr2},
+				PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+			)
+			assert.NoError(t, err)
+
+			account.Balance = new(big.Int).Add(account.Balance, big.NewInt(tt.delta))
+
+			err = AssertBalanceCoversStake(account)
+			if tt.succeed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrBalanceBelowStake)
+			}
+		})
+	}
+}
+
+func TestAssertWithdrawalSolvency(t *testing.T) {
+	tests := []struct {
+		name            string
+		delta           func(totalStaked *big.Int) *big.Int
+		maxRewardBuffer *big.Int
+		err             error
+	}{
+		{
+			"exactly solvent",
+			func(*big.Int) *big.Int { return big.NewInt(0) },
+			big.NewInt(0),
+			nil,
+		},
+		{
+			"solvent with an uncapped reward buffer",
+			func(totalStaked *big.Int) *big.Int { return new(big.Int).Mul(totalStaked, big.NewInt(10)) },
+			nil,
+			nil,
+		},
+		{
+			"reward buffer equal to the max reward buffer",
+			func(*big.Int) *big.Int { return big.NewInt(1e18) },
+			big.NewInt(1e18),
+			nil,
+		},
+		{
+			"insolvent",
+			func(*big.Int) *big.Int { return big.NewInt(-1) },
+			nil,
+			ErrBalanceBelowStake,
+		},
+		{
+			"reward buffer over the max reward buffer",
+			func(*big.Int) *big.Int { return big.NewInt(1e18 + 1) },
+			big.NewInt(1e18),
+			ErrRewardBufferTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := PredeployStakingSC(
+				[]types.Address{addr1, addr2},
+				PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+			)
+			assert.NoError(t, err)
+
+			totalStaked := new(big.Int).Set(account.Balance)
+			account.Balance = new(big.Int).Add(account.Balance, tt.delta(totalStaked))
+
+			assert.ErrorIs(t, AssertWithdrawalSolvency(account, tt.maxRewardBuffer), tt.err)
+		})
+	}
+
+	t.Run("should accept a funded account with no stake", func(t *testing.T) {
+		account, err := PredeployStakingSC(nil, PredeployParams{MinValidatorCount: 0, MaxValidatorCount: 10})
+		assert.NoError(t, err)
+
+		account.Balance = big.NewInt(1e18)
+
+		assert.NoError(t, AssertWithdrawalSolvency(account, nil))
+	})
+}
+
+func TestAssertBalanceEqualsStake(t *testing.T) {
+	tests := []struct {
+		name    string
+		delta   int64
+		succeed bool
+	}{
+		{"exact balance", 0, true},
+		{"one wei more", 1, false},
+		{"one wei less", -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := PredeployStakingSC(
+				[]types.Address{addr1, addr2},
+				PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+			)
+			assert.NoError(t, err)
+
+			account.Balance = new(big.Int).Add(account.Balance, big.NewInt(tt.delta))
+
+			err = AssertBalanceEqualsStake(account)
+			if tt.succeed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrBalanceNotEqualStake)
+			}
+		})
+	}
+}
+
+func TestAssertAllValidatorsStaked(t *testing.T) {
+	t.Run("should accept the predeployed storage", func(t *testing.T) {
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		assert.NoError(t, AssertAllValidatorsStaked(account))
+	})
+
+	t.Run("should reject a validator without stake", func(t *testing.T) {
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		delete(account.Storage, types.BytesToHash(getAddressMapping(addr2, addressToStakedAmountSlot)))
+
+		err = AssertAllValidatorsStaked(account)
+		assert.ErrorIs(t, err, ErrUnstakedValidator)
+		assert.Contains(t, err.Error(), addr2.String())
+		assert.NotContains(t, err.Error(), addr1.String())
+	})
+}
+
+func TestAssertValidatorBooleanConsistency(t *testing.T) {
+	predeploy := func(t *testing.T) *chain.GenesisAccount {
+		t.Helper()
+
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		return account
+	}
+
+	t.Run("should accept the predeployed storage", func(t *testing.T) {
+		assert.NoError(t, AssertValidatorBooleanConsistency(predeploy(t)))
+	})
+
+	t.Run("should reject a validator that is not flagged", func(t *testing.T) {
+		account := predeploy(t)
+
+		delete(account.Storage, types.BytesToHash(getAddressMapping(addr2, addressToIsValidatorSlot)))
+
+		err := AssertValidatorBooleanConsistency(account)
+		assert.ErrorIs(t, err, ErrValidatorFlagMismatch)
+		assert.Contains(t, err.Error(), addr2.String())
+	})
+
+	t.Run("should reject a flagged address removed from the array", func(t *testing.T) {
+		account := predeploy(t)
+
+		// Shrink the array, leaving the flag and the element of the last validator behind
+		account.Storage[SlotKey(validatorsSlot)] = types.BytesToHash(big.NewInt(1).Bytes())
+
+		err := AssertValidatorBooleanConsistency(account)
+		assert.ErrorIs(t, err, ErrValidatorFlagMismatch)
+		assert.Contains(t, err.Error(), addr2.String())
+	})
+}
+
+func TestCheckUniqueValidatorIndexes(t *testing.T) {
+	t.Run("should accept the predeployed storage", func(t *testing.T) {
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		assert.NoError(t, CheckUniqueValidatorIndexes(account))
+	})
+
+	t.Run("should detect a duplicated index", func(t *testing.T) {
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		// Point the second validator at the first array position
+		account.Storage[types.BytesToHash(getStorageIndexes(addr2, 1).AddressToValidatorIndexIndex)] = types.ZeroHash
+
+		assert.ErrorIs(t, CheckUniqueValidatorIndexes(account), ErrDuplicateValidatorIndex)
+	})
+
+	t.Run("should detect an index pointing at another position", func(t *testing.T) {
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		account.Storage[types.BytesToHash(getStorageIndexes(addr1, 0).AddressToValidatorIndexIndex)] = types.BytesToHash(
+			big.NewInt(1).Bytes(),
+		)
+
+		assert.ErrorIs(t, CheckUniqueValidatorIndexes(account), ErrValidatorIndexMismatch)
+	})
+
+	t.Run("should reject an index that doesn't fit in uint64", func(t *testing.T) {
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		// 2^64 + 1 truncates to the position of the second validator
+		overflowIndex := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(1))
+		account.Storage[types.BytesToHash(getStorageIndexes(addr2, 1).AddressToValidatorIndexIndex)] = types.BytesToHash(
+			overflowIndex.Bytes(),
+		)
+
+		assert.ErrorIs(t, CheckUniqueValidatorIndexes(account), ErrValidatorIndexMismatch)
+	})
+}
+
+func TestAssertNoExtraSlots(t *testing.T) {
+	validators := []types.Address{addr1, addr2}
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+
+	t.Run("should accept the predeployed storage", func(t *testing.T) {
+		account, err := PredeployStakingSC(validators, params)
+		assert.NoError(t, err)
+
+		assert.NoError(t, AssertNoExtraSlots(account, validators, params))
+	})
+
+	t.Run("should detect an extra slot", func(t *testing.T) {
+		account, err := PredeployStakingSC(validators, params)
+		assert.NoError(t, err)
+
+		account.Storage[SlotKey(100)] = types.BytesToHash(big.NewInt(1).Bytes())
+
+		assert.ErrorIs(t, AssertNoExtraSlots(account, validators, params), ErrUnexpectedStorageSlot)
+	})
+
+	t.Run("should detect the slots of a validator not in the set", func(t *testing.T) {
+		account, err := PredeployStakingSC(validators, params)
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, AssertNoExtraSlots(account, validators[:1], params), ErrUnexpectedStorageSlot)
+	})
+}
+
+func TestAssertValidatorsSorted(t *testing.T) {
+	addr3 := types.StringToAddress("3")
+
+	tests := []struct {
+		name       string
+		validators []types.Address
+		sort       bool
+		succeed    bool
+	}{
+		{"sorted", []types.Address{addr1, addr2, addr3}, false, true},
+		{"unsorted", []types.Address{addr1, addr3, addr2}, false, false},
+		{"unsorted with sort on write", []types.Address{addr3, addr1, addr2}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := PredeployStakingSC(
+				tt.validators,
+				PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10, SortValidators: tt.sort},
+			)
+			assert.NoError(t, err)
+
+			err = AssertValidatorsSorted(account)
+			if tt.succeed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrValidatorsNotSorted)
+			}
+		})
+	}
+}
+
+func TestValidateCodeLimits(t *testing.T) {
+	tests := []struct {
+		name                 string
+		codeSize             int
+		initCodeSize         int
+		forks                chain.ForksInTime
+		enforceInitCodeLimit bool
+		err                  error
+	}{
+		{"code at the size limit", state.SpuriousDragonMaxCodeSize, 0, chain.ForksInTime{EIP158: true}, false, nil},
+		{
+			"code over the size limit",
+			state.SpuriousDragonMaxCodeSize + 1,
+			0,
+			chain.ForksInTime{EIP158: true},
+			false,
+			runtime.ErrMaxCodeSizeExceeded,
+		},
+		{
+			"code over the size limit before EIP-158",
+			state.SpuriousDragonMaxCodeSize + 1,
+			0,
+			chain.ForksInTime{},
+			false,
+			nil,
+		},
+		{"init code at the size limit", 0, MaxInitCodeSize, chain.ForksInTime{EIP158: true}, true, nil},
+		{
+			"init code over the size limit",
+			0,
+			MaxInitCodeSize + 1,
+			chain.ForksInTime{EIP158: true},
+			true,
+			ErrMaxInitCodeSizeExceeded,
+		},
+		{
+			"init code over the size limit without the init code limit",
+			0,
+			MaxInitCodeSize + 1,
+			chain.ForksInTime{EIP158: true},
+			false,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &chain.GenesisAccount{
+				Code: make([]byte, tt.codeSize),
+			}
+
+			err := ValidateCodeLimits(account, make([]byte, tt.initCodeSize), tt.forks, tt.enforceInitCodeLimit)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAssertContiguousValidatorSlots(t *testing.T) {
+	predeploy := func(t *testing.T) *chain.GenesisAccount {
+		t.Helper()
+
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		return account
+	}
+
+	t.Run("should accept the predeployed storage", func(t *testing.T) {
+		assert.NoError(t, AssertContiguousValidatorSlots(predeploy(t)))
+	})
+
+	t.Run("should reject an empty element", func(t *testing.T) {
+		account := predeploy(t)
+
+		account.Storage[types.BytesToHash(getArrayElementIndex(validatorsSlot, 0))] = types.ZeroHash
+
+		assert.ErrorIs(t, AssertContiguousValidatorSlots(account), ErrValidatorSlotGap)
+	})
+
+	t.Run("should reject an element past the array size", func(t *testing.T) {
+		account := predeploy(t)
+
+		// The element at index 2 is skipped, so the one at index 3 is past a gap
+		account.Storage[types.BytesToHash(getArrayElementIndex(validatorsSlot, 3))] = types.BytesToHash(addr1.Bytes())
+
+		err := AssertContiguousValidatorSlots(account)
+		assert.ErrorIs(t, err, ErrValidatorSlotGap)
+		assert.Contains(t, err.Error(), "element 3")
+	})
+}
+
+func TestAssertContractAccountShape(t *testing.T) {
+	newAccount := func(t *testing.T) *chain.GenesisAccount {
+		t.Helper()
+
+		account, err := PredeployStakingSC(
+			[]types.Address{addr1, addr2},
+			PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10},
+		)
+		assert.NoError(t, err)
+
+		return account
+	}
+
+	tests := []struct {
+		name          string
+		mutate        func(account *chain.GenesisAccount)
+		expectedNonce uint64
+		expectedErr   error
+	}{
+		{"compliant predeploy", func(*chain.GenesisAccount) {}, 0, nil},
+		{
+			"compliant account without storage",
+			func(account *chain.GenesisAccount) {
+				account.Nonce = 1
+				account.Storage = nil
+			},
+			1,
+			nil,
+		},
+		{
+			"missing code",
+			func(account *chain.GenesisAccount) { account.Code = nil },
+			0,
+			ErrMissingContractCode,
+		},
+		{
+			"nonce mismatch",
+			func(*chain.GenesisAccount) {},
+			1,
+			ErrContractNonceMismatch,
+		},
+		{
+			"storage with only zero values",
+			func(account *chain.GenesisAccount) {
+				account.Storage = map[types.Hash]types.Hash{SlotKey(0): types.ZeroHash}
+			},
+			0,
+			ErrEmptyStorageRoot,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newAccount(t)
+			tt.mutate(account)
+
+			assert.ErrorIs(t, AssertContractAccountShape(account, tt.expectedNonce), tt.expectedErr)
+		})
+	}
+}
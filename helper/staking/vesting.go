@@ -0,0 +1,63 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrVestingNotSupported   = errors.New("staking SC doesn't support vesting")
+	ErrInvalidVestingStake   = errors.New("vesting stake must be a positive amount")
+	ErrUnlockTimeNotInFuture = errors.New("vesting unlock time must be after the genesis timestamp")
+)
+
+// VestingEntry is a pre-staked validator whose stake is locked
+// until the given unlock time (unix timestamp in seconds)
+type VestingEntry struct {
+	Address    types.Address
+	Stake      *big.Int
+	UnlockTime uint64
+}
+
+// PredeployStakingSCWithVesting is a helper method for setting up the staking smart contract account
+// of the given build, using the passed in vesting entries as pre-staked validators.
+// The unlock time of each registered validator is written to the AddressToUnlockTime mapping,
+// so the build must support vesting (ex. a build registered with RegisterStakingSCBuild)
+func PredeployStakingSCWithVesting(
+	build StakingSCBuild,
+	entries []VestingEntry,
+	genesisTimestamp uint64,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	stakedValidators := make([]stakedValidator, len(entries))
+	unlockTimes := make(map[types.Address]types.Hash, len(entries))
+
+	for indx, entry := range entries {
+		if entry.Stake == nil || entry.Stake.Sign() <= 0 {
+			return nil, fmt.Errorf("%w, validator %s", ErrInvalidVestingStake, entry.Address)
+		}
+
+		if entry.UnlockTime <= genesisTimestamp {
+			return nil, fmt.Errorf("%w, validator %s", ErrUnlockTimeNotInFuture, entry.Address)
+		}
+
+		stakedValidators[indx] = stakedValidator{
+			address: entry.Address,
+			stake:   entry.Stake,
+		}
+		unlockTimes[entry.Address] = types.BytesToHash(new(big.Int).SetUint64(entry.UnlockTime).Bytes())
+	}
+
+	return predeployStakingSCWithAddressMapping(
+		build,
+		stakedValidators,
+		params,
+		build.Slots.AddressToUnlockTime,
+		ErrVestingNotSupported,
+		unlockTimes,
+	)
+}
@@ -0,0 +1,83 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployStakingSCWithVesting(t *testing.T) {
+	var (
+		genesisTimestamp = uint64(1000)
+		params           = PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+		entries          = []VestingEntry{
+			{Address: addr1, Stake: big.NewInt(100), UnlockTime: 2000},
+			{Address: addr2, Stake: big.NewInt(200), UnlockTime: 3000},
+		}
+	)
+
+	build := defaultStakingSCBuild
+	build.Slots.AddressToUnlockTime = &testMappingSlot
+
+	t.Run("should write the unlock time", func(t *testing.T) {
+		account, err := PredeployStakingSCWithVesting(build, entries, genesisTimestamp, params)
+		assert.NoError(t, err)
+
+		for _, entry := range entries {
+			assert.Equal(
+				t,
+				types.BytesToHash(new(big.Int).SetUint64(entry.UnlockTime).Bytes()),
+				account.Storage[testMappingKeys[entry.Address]],
+			)
+		}
+
+		assert.Equal(t, big.NewInt(300), account.Balance)
+		assert.NoError(t, AssertBalanceEqualsStake(account))
+	})
+
+	t.Run("should not write the unlock times when staking is disabled", func(t *testing.T) {
+		disabledParams := params
+		disabledParams.DisableStaking = true
+
+		account, err := PredeployStakingSCWithVesting(build, entries, genesisTimestamp, disabledParams)
+		assert.NoError(t, err)
+
+		for _, entry := range entries {
+			assert.NotContains(t, account.Storage, testMappingKeys[entry.Address])
+		}
+	})
+
+	t.Run("should reject an unlock time slot over a core slot", func(t *testing.T) {
+		collidingBuild := defaultStakingSCBuild
+		collidingSlot := addressToStakedAmountSlot
+		collidingBuild.Slots.AddressToUnlockTime = &collidingSlot
+
+		_, err := PredeployStakingSCWithVesting(collidingBuild, entries, genesisTimestamp, params)
+		assert.ErrorIs(t, err, ErrSlotCollision)
+	})
+
+	t.Run("should fail for the embedded staking SC", func(t *testing.T) {
+		_, err := PredeployStakingSCWithVesting(defaultStakingSCBuild, entries, genesisTimestamp, params)
+		assert.ErrorIs(t, err, ErrVestingNotSupported)
+	})
+
+	t.Run("should reject an unlock time that is not in the future", func(t *testing.T) {
+		entries := []VestingEntry{
+			{Address: addr1, Stake: big.NewInt(100), UnlockTime: genesisTimestamp},
+		}
+
+		_, err := PredeployStakingSCWithVesting(build, entries, genesisTimestamp, params)
+		assert.ErrorIs(t, err, ErrUnlockTimeNotInFuture)
+	})
+
+	t.Run("should reject a missing stake", func(t *testing.T) {
+		entries := []VestingEntry{
+			{Address: addr1, UnlockTime: 2000},
+		}
+
+		_, err := PredeployStakingSCWithVesting(build, entries, genesisTimestamp, params)
+		assert.ErrorIs(t, err, ErrInvalidVestingStake)
+	})
+}
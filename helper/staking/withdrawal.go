@@ -0,0 +1,57 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrWithdrawalsNotSupported = errors.New("staking SC doesn't support withdrawal addresses")
+	ErrZeroWithdrawalAddress   = errors.New("withdrawal address must not be the zero address")
+)
+
+// ValidatorWithWithdrawal is a validator whose stake is withdrawn to
+// a different address than the one it signs with
+type ValidatorWithWithdrawal struct {
+	Signer     types.Address
+	Withdrawal types.Address
+}
+
+// PredeployStakingSCWithWithdrawals is a helper method for setting up the staking smart contract account
+// of the given build, using the signers of the passed in validators as pre-staked validators.
+// The withdrawal address of each registered validator is written to the AddressToWithdrawal mapping,
+// so the build must support withdrawal addresses (ex. a build registered with RegisterStakingSCBuild)
+func PredeployStakingSCWithWithdrawals(
+	build StakingSCBuild,
+	validators []ValidatorWithWithdrawal,
+	params PredeployParams,
+) (*chain.GenesisAccount, error) {
+	signers := make([]types.Address, len(validators))
+	withdrawals := make(map[types.Address]types.Hash, len(validators))
+
+	for indx, validator := range validators {
+		if validator.Withdrawal == types.ZeroAddress {
+			return nil, fmt.Errorf("%w, validator %s", ErrZeroWithdrawalAddress, validator.Signer)
+		}
+
+		signers[indx] = validator.Signer
+		withdrawals[validator.Signer] = types.BytesToHash(validator.Withdrawal.Bytes())
+	}
+
+	stakedValidators, err := defaultStakedValidators(signers)
+	if err != nil {
+		return nil, err
+	}
+
+	return predeployStakingSCWithAddressMapping(
+		build,
+		stakedValidators,
+		params,
+		build.Slots.AddressToWithdrawal,
+		ErrWithdrawalsNotSupported,
+		withdrawals,
+	)
+}
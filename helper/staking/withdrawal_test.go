@@ -0,0 +1,71 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredeployStakingSCWithWithdrawals(t *testing.T) {
+	params := PredeployParams{MinValidatorCount: 1, MaxValidatorCount: 10}
+	validators := []ValidatorWithWithdrawal{
+		{Signer: addr1, Withdrawal: types.StringToAddress("11")},
+		{Signer: addr2, Withdrawal: types.StringToAddress("22")},
+	}
+
+	build := defaultStakingSCBuild
+	build.Slots.AddressToWithdrawal = &testMappingSlot
+
+	t.Run("should write the withdrawal addresses", func(t *testing.T) {
+		account, err := PredeployStakingSCWithWithdrawals(build, validators, params)
+		assert.NoError(t, err)
+
+		for _, validator := range validators {
+			assert.Equal(
+				t,
+				types.BytesToHash(validator.Withdrawal.Bytes()),
+				account.Storage[testMappingKeys[validator.Signer]],
+			)
+		}
+
+		signers, err := DecodeValidators(account)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.Address{addr1, addr2}, signers)
+	})
+
+	t.Run("should not write the withdrawal addresses when staking is disabled", func(t *testing.T) {
+		disabledParams := params
+		disabledParams.DisableStaking = true
+
+		account, err := PredeployStakingSCWithWithdrawals(build, validators, disabledParams)
+		assert.NoError(t, err)
+
+		for _, validator := range validators {
+			assert.NotContains(t, account.Storage, testMappingKeys[validator.Signer])
+		}
+	})
+
+	t.Run("should reject a withdrawal slot over a core slot", func(t *testing.T) {
+		collidingBuild := defaultStakingSCBuild
+		collidingSlot := addressToIsValidatorSlot
+		collidingBuild.Slots.AddressToWithdrawal = &collidingSlot
+
+		_, err := PredeployStakingSCWithWithdrawals(collidingBuild, validators, params)
+		assert.ErrorIs(t, err, ErrSlotCollision)
+	})
+
+	t.Run("should fail for the embedded staking SC", func(t *testing.T) {
+		_, err := PredeployStakingSCWithWithdrawals(defaultStakingSCBuild, validators, params)
+		assert.ErrorIs(t, err, ErrWithdrawalsNotSupported)
+	})
+
+	t.Run("should reject a zero withdrawal address", func(t *testing.T) {
+		_, err := PredeployStakingSCWithWithdrawals(
+			build,
+			[]ValidatorWithWithdrawal{{Signer: addr1}},
+			params,
+		)
+		assert.ErrorIs(t, err, ErrZeroWithdrawalAddress)
+	})
+}